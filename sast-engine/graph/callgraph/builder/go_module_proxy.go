@@ -0,0 +1,290 @@
+package builder
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// defaultGoproxy is the module proxy used when GOPROXY is unset, matching the
+// Go toolchain's own default.
+const defaultGoproxy = "https://proxy.golang.org"
+
+// goModuleProxyHTTPClient is overridden in tests to point at a local
+// httptest.Server and to bound request latency.
+var goModuleProxyHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// ThirdPartyModuleIndex maps a third-party import path to the absolute
+// directory containing its source, fetched from a Go module proxy.
+type ThirdPartyModuleIndex map[string]string
+
+// InitGoModuleProxyLoader resolves every module in the project's go.mod
+// `require` block via the Go module proxy protocol (GOPROXY), downloads and
+// unzips each one into cacheDir, and returns a ThirdPartyModuleIndex mapping
+// each discovered package import path to its on-disk directory.
+//
+// GOPROXY resolution follows `go help goproxy`: a comma-separated list of
+// proxy URLs tried in order, "direct" (skipped — this loader has no VCS
+// fallback) and "off" (disables the loader entirely). When GOPROXY is unset,
+// https://proxy.golang.org is used, matching the toolchain default.
+//
+// Network or parse failures degrade gracefully: the failing module is
+// skipped (logged as a warning) and the loader continues with the rest,
+// mirroring InitGoStdlibLoader's offline-friendly behaviour.
+func InitGoModuleProxyLoader(projectPath, cacheDir string, logger *output.Logger) ThirdPartyModuleIndex {
+	index := make(ThirdPartyModuleIndex)
+
+	proxies := resolveGoproxyList()
+	if len(proxies) == 0 {
+		logger.Progress("GOPROXY=off — skipping third-party module source loading")
+		return index
+	}
+
+	requires, err := resolution.ParseGoModRequires(projectPath)
+	if err != nil {
+		logger.Warning("Failed to parse go.mod requires: %v", err)
+		return index
+	}
+
+	for _, req := range requires {
+		dir, fetchErr := fetchModuleViaProxy(proxies, req.Path, req.Version, cacheDir)
+		if fetchErr != nil {
+			logger.Warning("Failed to fetch %s@%s via GOPROXY: %v", req.Path, req.Version, fetchErr)
+			continue
+		}
+		indexModulePackages(index, req.Path, dir)
+		logger.Progress("Fetched %s@%s via GOPROXY into %s", req.Path, req.Version, dir)
+	}
+
+	return index
+}
+
+// resolveGoproxyList parses the GOPROXY environment variable into an ordered
+// list of proxy base URLs. "direct" entries are dropped (this loader cannot
+// clone VCS sources); "off" anywhere in the list disables fetching entirely.
+// An unset or empty GOPROXY falls back to defaultGoproxy.
+func resolveGoproxyList() []string {
+	raw := os.Getenv("GOPROXY")
+	if raw == "" {
+		return []string{defaultGoproxy}
+	}
+
+	var proxies []string
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		switch entry {
+		case "":
+			continue
+		case "off":
+			return nil
+		case "direct":
+			continue // no VCS fallback available to this loader
+		default:
+			proxies = append(proxies, strings.TrimSuffix(entry, "/"))
+		}
+	}
+	return proxies
+}
+
+// fetchModuleViaProxy downloads module@version's zip from the first proxy in
+// proxies that succeeds, caching the extracted source under
+// cacheDir/module@version and returning that directory. Already-cached
+// modules are reused without a network round-trip.
+func fetchModuleViaProxy(proxies []string, modulePath, version, cacheDir string) (string, error) {
+	escapedPath := escapeGoproxyPath(modulePath)
+
+	destDir := filepath.Join(cacheDir, escapedPath+"@"+version)
+	if info, statErr := os.Stat(destDir); statErr == nil && info.IsDir() {
+		return destDir, nil
+	}
+
+	var lastErr error
+	for _, base := range proxies {
+		if err := verifyModuleVersion(base, escapedPath, version); err != nil {
+			lastErr = err
+			continue
+		}
+		zipData, fetchErr := fetchProxyFile(base, escapedPath, version, "zip")
+		if fetchErr != nil {
+			lastErr = fetchErr
+			continue
+		}
+		if err := extractModuleZip(zipData, destDir); err != nil {
+			lastErr = err
+			continue
+		}
+		return destDir, nil
+	}
+	return "", fmt.Errorf("all proxies failed for %s@%s: %w", modulePath, version, lastErr)
+}
+
+// goModuleProxyInfo mirrors the JSON body of a proxy's {version}.info response.
+type goModuleProxyInfo struct {
+	Version string `json:"Version"`
+}
+
+// verifyModuleVersion fetches {version}.info from the proxy and confirms it
+// resolves to the version we're about to download, catching a proxy that
+// silently serves a different (e.g. retracted or canonicalised) version.
+func verifyModuleVersion(base, escapedPath, version string) error {
+	data, err := fetchProxyFile(base, escapedPath, version, "info")
+	if err != nil {
+		return err
+	}
+	var info goModuleProxyInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return fmt.Errorf("invalid %s.info response: %w", version, err)
+	}
+	if info.Version != version {
+		return fmt.Errorf("proxy resolved %s to %s, expected %s", escapedPath, info.Version, version)
+	}
+	return nil
+}
+
+// fetchProxyFile issues GET {base}/{escapedPath}/@v/{version}.{ext} and
+// returns the response body.
+func fetchProxyFile(base, escapedPath, version, ext string) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s/@v/%s.%s", base, escapedPath, version, ext)
+	resp, err := goModuleProxyHTTPClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy returned %s for %s", resp.Status, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// extractModuleZip unpacks a module proxy zip archive into destDir, stripping
+// the "module@version/" prefix every entry carries.
+func extractModuleZip(zipData []byte, destDir string) error {
+	reader, err := zip.NewReader(strings.NewReader(string(zipData)), int64(len(zipData)))
+	if err != nil {
+		return fmt.Errorf("invalid module zip: %w", err)
+	}
+
+	tmpDir := destDir + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, f := range reader.File {
+		relPath := stripModuleZipPrefix(f.Name)
+		if relPath == "" {
+			continue
+		}
+		if !isSafeModuleZipRelPath(relPath) {
+			return fmt.Errorf("module zip entry %q escapes extraction directory", f.Name)
+		}
+		outPath := filepath.Join(tmpDir, filepath.FromSlash(relPath))
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(outPath, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(outPath), 0o755); err != nil {
+			return err
+		}
+		if err := extractZipEntry(f, outPath); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(tmpDir, destDir)
+}
+
+// extractZipEntry writes a single zip file entry to outPath.
+func extractZipEntry(f *zip.File, outPath string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, rc)
+	return err
+}
+
+// stripModuleZipPrefix removes the leading "module@version/" directory every
+// entry in a proxy zip archive is namespaced under.
+func stripModuleZipPrefix(name string) string {
+	// The module path itself contains slashes, so locate the "@version"
+	// segment first and cut at the slash that follows it.
+	at := strings.Index(name, "@")
+	if at < 0 {
+		return ""
+	}
+	idx := strings.Index(name[at:], "/")
+	if idx < 0 {
+		return ""
+	}
+	return name[at+idx+1:]
+}
+
+// isSafeModuleZipRelPath reports whether relPath (already stripped of the
+// "module@version/" prefix by stripModuleZipPrefix) stays within the
+// extraction directory once cleaned — guarding against a zip-slip entry
+// (e.g. "../../../../tmp/evil.go" or an absolute path) in a malicious or
+// MITM'd proxy response, since GOPROXY is a user-configurable env var and
+// not every configured proxy URL is HTTPS-pinned.
+func isSafeModuleZipRelPath(relPath string) bool {
+	cleaned := filepath.Clean(filepath.FromSlash(relPath))
+	if filepath.IsAbs(cleaned) {
+		return false
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return false
+	}
+	return true
+}
+
+// indexModulePackages walks a fetched module's extracted directory and adds
+// every Go package it finds to index, keyed by import path (modulePath plus
+// the package's relative path).
+func indexModulePackages(index ThirdPartyModuleIndex, modulePath, dir string) {
+	reg, err := resolution.BuildGoModuleRegistry(dir)
+	if err != nil {
+		// The module's own go.mod may have a different module path than
+		// modulePath (rare, but replace-adjacent); fall back to indexing
+		// just the root directory so callers still have a starting point.
+		index[modulePath] = dir
+		return
+	}
+	for nestedDir, importPath := range reg.DirToImport {
+		index[strings.Replace(importPath, reg.ModulePath, modulePath, 1)] = nestedDir
+	}
+}
+
+// escapeGoproxyPath applies the module proxy's escaped-path encoding (each
+// uppercase letter becomes "!" + its lowercase form), per `go help goproxy`.
+func escapeGoproxyPath(modulePath string) string {
+	var b strings.Builder
+	for _, r := range modulePath {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('!')
+			b.WriteRune(r - 'A' + 'a')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,209 @@
+package builder
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProxyZip builds a minimal module zip archive (as served by a Go
+// module proxy) containing the given files under "module@version/".
+func newTestProxyZip(t *testing.T, moduleAtVersion string, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(moduleAtVersion + "/" + name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func newTestProxyServer(t *testing.T, modulePath, version string, zipData []byte) *httptest.Server {
+	t.Helper()
+	escaped := escapeGoproxyPath(modulePath)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/%s/@v/%s.info", escaped, version):
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"Version":"%s"}`, version)
+		case fmt.Sprintf("/%s/@v/%s.zip", escaped, version):
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(zipData)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestResolveGoproxyList_Default(t *testing.T) {
+	t.Setenv("GOPROXY", "")
+	assert.Equal(t, []string{defaultGoproxy}, resolveGoproxyList())
+}
+
+func TestResolveGoproxyList_Off(t *testing.T) {
+	t.Setenv("GOPROXY", "off")
+	assert.Nil(t, resolveGoproxyList())
+}
+
+func TestResolveGoproxyList_CommaSeparatedWithDirect(t *testing.T) {
+	t.Setenv("GOPROXY", "https://proxy1.example,direct,https://proxy2.example")
+	assert.Equal(t, []string{"https://proxy1.example", "https://proxy2.example"}, resolveGoproxyList())
+}
+
+func TestEscapeGoproxyPath(t *testing.T) {
+	assert.Equal(t, "github.com/!azure/azure-sdk-for-go", escapeGoproxyPath("github.com/Azure/azure-sdk-for-go"))
+	assert.Equal(t, "github.com/gorilla/mux", escapeGoproxyPath("github.com/gorilla/mux"))
+}
+
+func TestFetchModuleViaProxy_FetchesAndExtracts(t *testing.T) {
+	zipData := newTestProxyZip(t, "github.com/gorilla/mux@v1.8.0", map[string]string{
+		"go.mod":  "module github.com/gorilla/mux\n\ngo 1.16\n",
+		"mux.go":  "package mux\n",
+	})
+	server := newTestProxyServer(t, "github.com/gorilla/mux", "v1.8.0", zipData)
+	defer server.Close()
+
+	original := goModuleProxyHTTPClient
+	t.Cleanup(func() { goModuleProxyHTTPClient = original })
+	goModuleProxyHTTPClient = server.Client()
+
+	cacheDir := t.TempDir()
+	dir, err := fetchModuleViaProxy([]string{server.URL}, "github.com/gorilla/mux", "v1.8.0", cacheDir)
+	require.NoError(t, err)
+
+	content, readErr := os.ReadFile(filepath.Join(dir, "mux.go"))
+	require.NoError(t, readErr)
+	assert.Equal(t, "package mux\n", string(content))
+}
+
+func TestFetchModuleViaProxy_UsesCacheOnSecondCall(t *testing.T) {
+	zipData := newTestProxyZip(t, "github.com/gorilla/mux@v1.8.0", map[string]string{
+		"go.mod": "module github.com/gorilla/mux\n\ngo 1.16\n",
+	})
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		escaped := escapeGoproxyPath("github.com/gorilla/mux")
+		switch r.URL.Path {
+		case fmt.Sprintf("/%s/@v/v1.8.0.info", escaped):
+			fmt.Fprint(w, `{"Version":"v1.8.0"}`)
+		case fmt.Sprintf("/%s/@v/v1.8.0.zip", escaped):
+			_, _ = w.Write(zipData)
+		}
+	}))
+	defer server.Close()
+
+	original := goModuleProxyHTTPClient
+	t.Cleanup(func() { goModuleProxyHTTPClient = original })
+	goModuleProxyHTTPClient = server.Client()
+
+	cacheDir := t.TempDir()
+	_, err := fetchModuleViaProxy([]string{server.URL}, "github.com/gorilla/mux", "v1.8.0", cacheDir)
+	require.NoError(t, err)
+	firstCalls := calls
+
+	_, err = fetchModuleViaProxy([]string{server.URL}, "github.com/gorilla/mux", "v1.8.0", cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, firstCalls, calls, "second fetch should be served from cache, not the network")
+}
+
+func TestExtractModuleZip_RejectsZipSlipEntry(t *testing.T) {
+	outsideDir := t.TempDir()
+	destDir := filepath.Join(t.TempDir(), "github.com/evil/mod@v1.0.0")
+
+	zipData := newTestProxyZip(t, "github.com/evil/mod@v1.0.0", map[string]string{
+		"../../../../" + filepath.Base(outsideDir) + "/evil.go": "package evil\n",
+	})
+
+	err := extractModuleZip(zipData, destDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "escapes extraction directory")
+
+	_, statErr := os.Stat(filepath.Join(outsideDir, "evil.go"))
+	assert.True(t, os.IsNotExist(statErr), "zip-slip entry must not be written outside the extraction directory")
+}
+
+func TestExtractModuleZip_AbsolutePathEntryRejected(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "github.com/evil/mod@v1.0.0")
+
+	zipData := newTestProxyZip(t, "github.com/evil/mod@v1.0.0", map[string]string{
+		"/etc/evil.go": "package evil\n",
+	})
+
+	err := extractModuleZip(zipData, destDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "escapes extraction directory")
+}
+
+func TestExtractModuleZip_WellFormedEntriesStillExtract(t *testing.T) {
+	destDir := filepath.Join(t.TempDir(), "github.com/gorilla/mux@v1.8.0")
+
+	zipData := newTestProxyZip(t, "github.com/gorilla/mux@v1.8.0", map[string]string{
+		"go.mod": "module github.com/gorilla/mux\n\ngo 1.16\n",
+		"mux.go": "package mux\n",
+	})
+
+	require.NoError(t, extractModuleZip(zipData, destDir))
+
+	content, err := os.ReadFile(filepath.Join(destDir, "mux.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package mux\n", string(content))
+}
+
+func TestInitGoModuleProxyLoader_Off(t *testing.T) {
+	t.Setenv("GOPROXY", "off")
+	logger := newGoVersionTestLogger()
+	index := InitGoModuleProxyLoader(t.TempDir(), t.TempDir(), logger)
+	assert.Empty(t, index)
+}
+
+func TestInitGoModuleProxyLoader_DegradesOnNetworkError(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/app\n\ngo 1.21\n\nrequire github.com/gorilla/mux v1.8.0\n")
+
+	original := goModuleProxyHTTPClient
+	t.Cleanup(func() { goModuleProxyHTTPClient = original })
+	goModuleProxyHTTPClient = &http.Client{}
+
+	t.Setenv("GOPROXY", "http://127.0.0.1:0")
+	logger := newGoVersionTestLogger()
+	index := InitGoModuleProxyLoader(dir, t.TempDir(), logger)
+	assert.Empty(t, index, "network failures should degrade gracefully, not panic or error")
+}
+
+func TestInitGoModuleProxyLoader_Success(t *testing.T) {
+	zipData := newTestProxyZip(t, "github.com/gorilla/mux@v1.8.0", map[string]string{
+		"go.mod": "module github.com/gorilla/mux\n\ngo 1.16\n",
+		"mux.go": "package mux\n",
+	})
+	server := newTestProxyServer(t, "github.com/gorilla/mux", "v1.8.0", zipData)
+	defer server.Close()
+
+	original := goModuleProxyHTTPClient
+	t.Cleanup(func() { goModuleProxyHTTPClient = original })
+	goModuleProxyHTTPClient = server.Client()
+
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/app\n\ngo 1.21\n\nrequire github.com/gorilla/mux v1.8.0\n")
+
+	t.Setenv("GOPROXY", server.URL)
+	logger := newGoVersionTestLogger()
+	index := InitGoModuleProxyLoader(dir, t.TempDir(), logger)
+
+	dirFound, ok := index["github.com/gorilla/mux"]
+	require.True(t, ok)
+	_, statErr := os.Stat(filepath.Join(dirFound, "mux.go"))
+	assert.NoError(t, statErr)
+}
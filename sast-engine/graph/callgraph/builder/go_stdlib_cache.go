@@ -0,0 +1,159 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// stdlibManifestCacheTTL is how long a cached manifest is trusted before a
+// fresh fetch is attempted. Stdlib manifests change only on a new Go release,
+// so a generous TTL avoids refetching on every run.
+const stdlibManifestCacheTTL = 7 * 24 * time.Hour
+
+// stdlibCacheHTTPClient is overridden in tests to point at a local
+// httptest.Server.
+var stdlibCacheHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// stdlibManifestCacheDir returns the directory cached stdlib manifests for
+// projectPath are stored in. The cache lives under the project itself (like
+// a build cache) rather than a global user cache dir, so concurrent runs
+// against different projects never share or clobber each other's cache.
+func stdlibManifestCacheDir(projectPath string) string {
+	return filepath.Join(projectPath, ".codepathfinder", "cache", "stdlib")
+}
+
+// stdlibManifestCachePath returns the cache file path for a given Go version.
+func stdlibManifestCachePath(projectPath, version string) string {
+	return filepath.Join(stdlibManifestCacheDir(projectPath), "go"+version+".json")
+}
+
+// cachedGoManifestLoader implements core.GoStdlibLoader from a manifest held
+// entirely in memory (loaded from disk or freshly fetched), indexed by import
+// path for O(1) package lookups.
+type cachedGoManifestLoader struct {
+	packages map[string]*core.GoPackageEntry
+}
+
+// newCachedGoManifestLoader indexes manifest.Packages by import path.
+func newCachedGoManifestLoader(manifest *core.GoManifest) *cachedGoManifestLoader {
+	l := &cachedGoManifestLoader{packages: make(map[string]*core.GoPackageEntry, len(manifest.Packages))}
+	for _, pkg := range manifest.Packages {
+		l.packages[pkg.ImportPath] = pkg
+	}
+	return l
+}
+
+// ValidateStdlibImport reports whether importPath is present in the manifest.
+func (l *cachedGoManifestLoader) ValidateStdlibImport(importPath string) bool {
+	_, ok := l.packages[importPath]
+	return ok
+}
+
+// GetFunction looks up a function by name within importPath's package entry.
+func (l *cachedGoManifestLoader) GetFunction(importPath, funcName string) (*core.GoStdlibFunction, error) {
+	pkg, ok := l.packages[importPath]
+	if !ok {
+		return nil, fmt.Errorf("package %q not in cached stdlib manifest", importPath)
+	}
+	for _, fn := range pkg.Functions {
+		if fn.Name == funcName {
+			return fn, nil
+		}
+	}
+	return nil, fmt.Errorf("function %s.%s not in cached stdlib manifest", importPath, funcName)
+}
+
+// GetType looks up a type by name within importPath's package entry.
+func (l *cachedGoManifestLoader) GetType(importPath, typeName string) (*core.GoStdlibType, error) {
+	pkg, ok := l.packages[importPath]
+	if !ok {
+		return nil, fmt.Errorf("package %q not in cached stdlib manifest", importPath)
+	}
+	for _, t := range pkg.Types {
+		if t.Name == typeName {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("type %s.%s not in cached stdlib manifest", importPath, typeName)
+}
+
+// PackageCount returns the number of packages the manifest describes.
+func (l *cachedGoManifestLoader) PackageCount() int {
+	return len(l.packages)
+}
+
+// loadStdlibManifestOffline reads a previously-cached manifest for version
+// from disk, if one exists and is still within stdlibManifestCacheTTL. This
+// is the offline path: no network access is attempted.
+func loadStdlibManifestOffline(projectPath, version string) (*cachedGoManifestLoader, bool) {
+	path := stdlibManifestCachePath(projectPath, version)
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > stdlibManifestCacheTTL {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var manifest core.GoManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, false
+	}
+	return newCachedGoManifestLoader(&manifest), true
+}
+
+// fetchAndCacheStdlibManifest fetches {baseURL}/go{version}/manifest.json,
+// writes it to projectPath's on-disk cache for loadStdlibManifestOffline to
+// pick up on a future run, and returns a loader built from it along with the
+// number of bytes transferred (for trace logging — see stdlibTraceSpan).
+//
+// ctx is attached to the outbound request so that cancelling it (a deadline,
+// or the process receiving SIGINT) aborts the fetch cleanly instead of
+// blocking until the HTTP client's own timeout elapses.
+func fetchAndCacheStdlibManifest(ctx context.Context, baseURL, version, projectPath string) (*cachedGoManifestLoader, int, error) {
+	url := fmt.Sprintf("%s/go%s/manifest.json", baseURL, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := stdlibCacheHTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("stdlib registry returned %s for %s", resp.Status, url)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var manifest core.GoManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, 0, fmt.Errorf("invalid stdlib manifest for go%s: %w", version, err)
+	}
+
+	// Caching is best-effort — a write failure must not fail the load.
+	_ = writeStdlibManifestCache(projectPath, version, data)
+
+	return newCachedGoManifestLoader(&manifest), len(data), nil
+}
+
+// writeStdlibManifestCache persists the raw manifest bytes for version to disk.
+func writeStdlibManifestCache(projectPath, version string, data []byte) error {
+	path := stdlibManifestCachePath(projectPath, version)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
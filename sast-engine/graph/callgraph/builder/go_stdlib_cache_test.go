@@ -0,0 +1,107 @@
+package builder
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchAndCacheStdlibManifest_WritesCacheFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(minimalManifest())
+	}))
+	defer server.Close()
+
+	original := stdlibCacheHTTPClient
+	t.Cleanup(func() { stdlibCacheHTTPClient = original })
+	stdlibCacheHTTPClient = server.Client()
+
+	dir := t.TempDir()
+	loader, bytes, err := fetchAndCacheStdlibManifest(context.Background(), server.URL, "1.21", dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loader.PackageCount())
+	assert.Positive(t, bytes, "should report the number of bytes transferred for trace logging")
+
+	_, statErr := os.Stat(stdlibManifestCachePath(dir, "1.21"))
+	assert.NoError(t, statErr, "manifest should be written to the on-disk cache")
+}
+
+func TestLoadStdlibManifestOffline_UsesCacheWithoutNetwork(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, writeStdlibManifestCache(dir, "1.21", minimalManifest()))
+
+	loader, ok := loadStdlibManifestOffline(dir, "1.21")
+	require.True(t, ok)
+	assert.Equal(t, 2, loader.PackageCount())
+	assert.True(t, loader.ValidateStdlibImport("fmt"))
+}
+
+func TestLoadStdlibManifestOffline_MissingCache(t *testing.T) {
+	_, ok := loadStdlibManifestOffline(t.TempDir(), "1.21")
+	assert.False(t, ok)
+}
+
+func TestLoadStdlibManifestOffline_ExpiredCacheIgnored(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, writeStdlibManifestCache(dir, "1.21", minimalManifest()))
+
+	path := stdlibManifestCachePath(dir, "1.21")
+	stale := time.Now().Add(-2 * stdlibManifestCacheTTL)
+	require.NoError(t, os.Chtimes(path, stale, stale))
+
+	_, ok := loadStdlibManifestOffline(dir, "1.21")
+	assert.False(t, ok, "a manifest older than the TTL must not be trusted")
+}
+
+func TestInitGoStdlibLoader_SecondRunUsesCacheWithoutNetwork(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(minimalManifest())
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	reg := core.NewGoModuleRegistry()
+	reg.GoVersion = "1.21"
+	logger := newGoVersionTestLogger()
+
+	initGoStdlibLoaderWithBase(context.Background(), reg, dir, logger, server.URL)
+	require.NotNil(t, reg.StdlibLoader)
+	firstCalls := calls
+
+	reg2 := core.NewGoModuleRegistry()
+	reg2.GoVersion = "1.21"
+	initGoStdlibLoaderWithBase(context.Background(), reg2, dir, logger, server.URL)
+	require.NotNil(t, reg2.StdlibLoader)
+	assert.Equal(t, firstCalls, calls, "second init should be served from the on-disk cache, not the network")
+}
+
+func TestInitGoStdlibLoader_OfflineFallbackWhenNetworkUnavailable(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, writeStdlibManifestCache(dir, "1.21", minimalManifest()))
+
+	reg := core.NewGoModuleRegistry()
+	reg.GoVersion = "1.21"
+	logger := newGoVersionTestLogger()
+
+	initGoStdlibLoaderWithBase(context.Background(), reg, dir, logger, "http://127.0.0.1:0")
+
+	require.NotNil(t, reg.StdlibLoader, "an unexpired local cache must be usable with no network access")
+	assert.Equal(t, 2, reg.StdlibLoader.PackageCount())
+}
+
+func TestStdlibManifestCacheDir_ScopedToProject(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, filepath.Join(dir, ".codepathfinder", "cache", "stdlib"), stdlibManifestCacheDir(dir))
+}
@@ -0,0 +1,344 @@
+package builder
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+//go:embed embedded_core_manifest.json
+var embeddedCoreManifestJSON []byte
+
+// embeddedCorePackages is the set of stdlib packages bundled directly into
+// the binary (see embedded_core_manifest.json) — fmt, os, io, net/http,
+// strings, bytes, errors, context, encoding/json — so import validation for
+// the handful of packages nearly every Go program touches works with zero
+// network access and no prior cache. Only package names are bundled, not
+// function signatures; those still come from a per-package shard fetch/cache.
+var embeddedCorePackages = loadEmbeddedCorePackages()
+
+func loadEmbeddedCorePackages() map[string]bool {
+	var index stdlibPackageIndex
+	if err := json.Unmarshal(embeddedCoreManifestJSON, &index); err != nil {
+		return map[string]bool{}
+	}
+	return toPackageSet(index.Packages)
+}
+
+// stdlibPackageIndex is the lightweight package-list index fetched ahead of
+// any per-package shard — enough to answer ValidateStdlibImport without
+// downloading every package's function signatures up front.
+type stdlibPackageIndex struct {
+	Packages []string `json:"packages"`
+}
+
+func toPackageSet(packages []string) map[string]bool {
+	set := make(map[string]bool, len(packages))
+	for _, pkg := range packages {
+		set[pkg] = true
+	}
+	return set
+}
+
+// lazyGoStdlibLoader implements core.GoStdlibLoader by fetching only a
+// lightweight package-list index eagerly, then fetching per-package JSON
+// shards lazily on first access to GetFunction/GetType (keyed by
+// (version, importPath)) — rather than downloading the whole stdlib manifest
+// up front the way loadStdlibManifestForVersion's cachedGoManifestLoader
+// does. This matters for SAST runs that only touch a handful of stdlib
+// packages, and for offline=true runs, which must still answer import
+// validation from the embedded core manifest even with nothing cached.
+//
+// Cache layout intentionally reuses stdlibManifestCacheDir's project-local
+// root (.codepathfinder/cache/stdlib), not a global XDG_CACHE_HOME tree:
+// that choice was already made deliberately for the whole-manifest cache
+// (see stdlibManifestCacheDir's doc comment — concurrent runs against
+// different projects must never share or clobber each other's cache), and
+// the same reasoning applies just as much to per-package shards.
+type lazyGoStdlibLoader struct {
+	ctx         context.Context
+	baseURL     string
+	version     string
+	projectPath string
+	offline     bool
+	logger      *output.Logger
+
+	index map[string]bool
+
+	mu       sync.Mutex
+	shards   map[string]*core.GoPackageEntry
+	shardErr map[string]error
+}
+
+// NewLazyGoStdlibLoader loads (from cache, or the network unless offline)
+// version's package-list index and returns a loader that defers per-package
+// shard fetches until GetFunction/GetType actually need one.
+//
+// When offline is true, no network call is ever made: the index is served
+// from the on-disk cache or, failing that, the embedded core manifest, and
+// shards only from the on-disk cache (the embedded manifest holds package
+// names, not shards) — a missing shard in offline mode is a normal miss (the
+// function simply isn't found), never a network error.
+//
+// ctx is retained for the lifetime of the loader and attached to every
+// subsequent CDN request it makes — including the lazy per-package shard
+// fetches triggered by GetFunction/GetType, which happen well after this
+// call returns. core.GoStdlibLoader's GetFunction/GetType methods take no
+// context of their own, so this is the only cancellation point available;
+// cancelling ctx aborts whichever fetch is in flight and memoizes the
+// resulting error (see loadShard) rather than retrying.
+//
+// Returns an error only when the index can't be obtained from cache, the
+// network (if permitted), or the embedded core manifest — i.e. there is
+// nothing at all to validate stdlib imports against.
+func NewLazyGoStdlibLoader(ctx context.Context, baseURL, version, projectPath string, offline bool, logger *output.Logger) (*lazyGoStdlibLoader, error) {
+	loader := &lazyGoStdlibLoader{
+		ctx:         ctx,
+		baseURL:     baseURL,
+		version:     version,
+		projectPath: projectPath,
+		offline:     offline,
+		logger:      logger,
+		shards:      make(map[string]*core.GoPackageEntry),
+		shardErr:    make(map[string]error),
+	}
+
+	index, err := loader.loadIndex()
+	if err != nil {
+		if len(embeddedCorePackages) == 0 {
+			return nil, err
+		}
+		index = nil // fall through to the embedded core packages below
+	}
+
+	loader.index = make(map[string]bool, len(index)+len(embeddedCorePackages))
+	for pkg := range index {
+		loader.index[pkg] = true
+	}
+	for pkg := range embeddedCorePackages {
+		loader.index[pkg] = true
+	}
+	return loader, nil
+}
+
+// loadIndex loads the package-list index from the on-disk cache if present,
+// else (unless offline) fetches it from baseURL and caches it for next time.
+func (l *lazyGoStdlibLoader) loadIndex() (map[string]bool, error) {
+	start := time.Now()
+	path := stdlibIndexCachePath(l.projectPath, l.version)
+	if data, err := os.ReadFile(path); err == nil {
+		var idx stdlibPackageIndex
+		if err := json.Unmarshal(data, &idx); err == nil {
+			stdlibTraceSpan(l.logger, "load_index", l.version, true, len(data), time.Since(start))
+			return toPackageSet(idx.Packages), nil
+		}
+	}
+
+	if l.offline {
+		return nil, fmt.Errorf("offline mode: no cached stdlib package index for go%s", l.version)
+	}
+
+	url := fmt.Sprintf("%s/go%s/index.json", l.baseURL, l.version)
+	req, err := http.NewRequestWithContext(l.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := stdlibCacheHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("stdlib registry returned %s for %s", resp.Status, url)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var idx stdlibPackageIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("invalid stdlib package index for go%s: %w", l.version, err)
+	}
+
+	// Caching is best-effort — a write failure must not fail the load.
+	if mkErr := os.MkdirAll(filepath.Dir(path), 0o755); mkErr == nil {
+		_ = os.WriteFile(path, data, 0o600)
+	}
+
+	stdlibTraceSpan(l.logger, "load_index", l.version, false, len(data), time.Since(start))
+	return toPackageSet(idx.Packages), nil
+}
+
+// ValidateStdlibImport reports whether importPath is present in the
+// package-list index (including the embedded core packages).
+func (l *lazyGoStdlibLoader) ValidateStdlibImport(importPath string) bool {
+	return l.index[importPath]
+}
+
+// GetFunction lazily loads importPath's shard (from cache, network, or
+// neither if both are unavailable) and looks up funcName within it.
+func (l *lazyGoStdlibLoader) GetFunction(importPath, funcName string) (*core.GoStdlibFunction, error) {
+	pkg, err := l.loadShard(importPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range pkg.Functions {
+		if fn.Name == funcName {
+			return fn, nil
+		}
+	}
+	return nil, fmt.Errorf("function %s.%s not in stdlib shard", importPath, funcName)
+}
+
+// GetType lazily loads importPath's shard and looks up typeName within it.
+func (l *lazyGoStdlibLoader) GetType(importPath, typeName string) (*core.GoStdlibType, error) {
+	pkg, err := l.loadShard(importPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range pkg.Types {
+		if t.Name == typeName {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("type %s.%s not in stdlib shard", importPath, typeName)
+}
+
+// PackageCount returns the number of packages the index describes.
+func (l *lazyGoStdlibLoader) PackageCount() int {
+	return len(l.index)
+}
+
+// loadShard returns importPath's cached shard, fetching (and caching) it on
+// first access. Both successful loads and failures are memoized so a
+// repeatedly-referenced missing package doesn't retry the fetch/cache-miss
+// path on every call.
+func (l *lazyGoStdlibLoader) loadShard(importPath string) (*core.GoPackageEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if pkg, ok := l.shards[importPath]; ok {
+		return pkg, nil
+	}
+	if err, ok := l.shardErr[importPath]; ok {
+		return nil, err
+	}
+
+	pkg, err := l.fetchShard(importPath)
+	if err != nil {
+		l.shardErr[importPath] = err
+		return nil, err
+	}
+	l.shards[importPath] = pkg
+	return pkg, nil
+}
+
+// fetchShard loads importPath's shard from the on-disk cache if present,
+// else (unless offline) fetches it from the CDN and caches it.
+func (l *lazyGoStdlibLoader) fetchShard(importPath string) (*core.GoPackageEntry, error) {
+	start := time.Now()
+	path := stdlibShardCachePath(l.projectPath, l.version, importPath)
+	if data, err := os.ReadFile(path); err == nil {
+		var pkg core.GoPackageEntry
+		if err := json.Unmarshal(data, &pkg); err == nil {
+			stdlibTraceSpan(l.logger, "load_shard:"+importPath, l.version, true, len(data), time.Since(start))
+			return &pkg, nil
+		}
+	}
+
+	if l.offline {
+		return nil, fmt.Errorf("offline mode: no cached stdlib shard for %s (go%s)", importPath, l.version)
+	}
+
+	url := fmt.Sprintf("%s/go%s/packages/%s.json", l.baseURL, l.version, importPath)
+	req, err := http.NewRequestWithContext(l.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := stdlibCacheHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("stdlib registry returned %s for %s", resp.Status, url)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var pkg core.GoPackageEntry
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("invalid stdlib package shard for %s: %w", importPath, err)
+	}
+
+	// Caching is best-effort — a write failure must not fail the load.
+	if mkErr := os.MkdirAll(filepath.Dir(path), 0o755); mkErr == nil {
+		_ = os.WriteFile(path, data, 0o600)
+	}
+
+	stdlibTraceSpan(l.logger, "load_shard:"+importPath, l.version, false, len(data), time.Since(start))
+	return &pkg, nil
+}
+
+// stdlibLazyCacheDir is the per-version root for lazily-fetched index and
+// shard files, nested under the same project-local cache root the
+// whole-manifest path (go_stdlib_cache.go) already uses.
+func stdlibLazyCacheDir(projectPath, version string) string {
+	return filepath.Join(stdlibManifestCacheDir(projectPath), version)
+}
+
+// stdlibIndexCachePath is the cache file path for a version's package-list index.
+func stdlibIndexCachePath(projectPath, version string) string {
+	return filepath.Join(stdlibLazyCacheDir(projectPath, version), "index.json")
+}
+
+// stdlibShardCachePath is the cache file path for a single package's shard.
+// importPath's slashes become nested directories (e.g. "net/http" →
+// .../packages/net/http.json), mirroring the import path's own structure.
+func stdlibShardCachePath(projectPath, version, importPath string) string {
+	return filepath.Join(stdlibLazyCacheDir(projectPath, version), "packages", filepath.FromSlash(importPath)+".json")
+}
+
+// InitGoStdlibLoaderLazy is InitGoStdlibLoader's lazy, offline-capable
+// sibling: instead of downloading the whole stdlib manifest up front, it
+// fetches only the lightweight package-list index and lets GetFunction/
+// GetType pull individual package shards on demand (see
+// lazyGoStdlibLoader). Pass offline=true to refuse all network calls and
+// serve strictly from the on-disk cache plus the embedded core manifest.
+//
+// Version resolution mirrors initGoStdlibLoaderWithBase: a "toolchain"
+// directive in go.mod takes priority, then reg.GoVersion, then full
+// DetectGoVersion.
+//
+// ctx is handed to NewLazyGoStdlibLoader and retained for the loader's
+// lifetime (see its doc comment) so that both the initial index fetch and
+// every later per-package shard fetch can be cancelled or deadlined by the
+// caller.
+func InitGoStdlibLoaderLazy(ctx context.Context, reg *core.GoModuleRegistry, projectPath string, logger *output.Logger, offline bool) {
+	version := normalizeGoVersion(parseGoVersionFromFile(filepath.Join(projectPath, "go.mod")))
+	if version == "" {
+		version = normalizeGoVersion(reg.GoVersion)
+	}
+	if version == "" {
+		version = DetectGoVersion(projectPath)
+	}
+
+	loader, err := NewLazyGoStdlibLoader(ctx, stdlibRegistryBaseURL, version, projectPath, offline, logger)
+	if err != nil {
+		logger.Warning("Failed to initialize lazy Go %s stdlib loader: %v", version, err)
+		return
+	}
+	logger.Progress("Initialized lazy Go %s stdlib loader (%d packages indexed)", version, loader.PackageCount())
+	reg.StdlibLoader = loader
+}
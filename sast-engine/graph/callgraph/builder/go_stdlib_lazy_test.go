@@ -0,0 +1,156 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLazyGoStdlibLoader_FetchesIndexAndCachesIt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Contains(t, r.URL.Path, "index.json")
+		data, _ := json.Marshal(stdlibPackageIndex{Packages: []string{"fmt", "os"}})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	loader, err := NewLazyGoStdlibLoader(context.Background(), server.URL, "1.21", dir, false, newGoVersionTestLogger())
+	require.NoError(t, err)
+	assert.True(t, loader.ValidateStdlibImport("fmt"))
+	assert.True(t, loader.ValidateStdlibImport("os"))
+
+	_, statErr := os.Stat(stdlibIndexCachePath(dir, "1.21"))
+	assert.NoError(t, statErr, "index should be written to the on-disk cache")
+}
+
+func TestNewLazyGoStdlibLoader_UsesCachedIndexWithoutNetwork(t *testing.T) {
+	dir := t.TempDir()
+	data, _ := json.Marshal(stdlibPackageIndex{Packages: []string{"fmt"}})
+	require.NoError(t, os.MkdirAll(filepath.Dir(stdlibIndexCachePath(dir, "1.21")), 0o755))
+	require.NoError(t, os.WriteFile(stdlibIndexCachePath(dir, "1.21"), data, 0o600))
+
+	loader, err := NewLazyGoStdlibLoader(context.Background(), "http://127.0.0.1:0", "1.21", dir, true, newGoVersionTestLogger())
+	require.NoError(t, err)
+	assert.True(t, loader.ValidateStdlibImport("fmt"))
+}
+
+func TestNewLazyGoStdlibLoader_OfflineWithoutCache_FallsBackToEmbeddedCore(t *testing.T) {
+	loader, err := NewLazyGoStdlibLoader(context.Background(), "http://127.0.0.1:0", "1.21", t.TempDir(), true, newGoVersionTestLogger())
+	require.NoError(t, err)
+	assert.True(t, loader.ValidateStdlibImport("fmt"), "fmt is part of the embedded core manifest")
+	assert.False(t, loader.ValidateStdlibImport("github.com/example/pkg"))
+}
+
+func TestLazyGoStdlibLoader_GetFunction_FetchesShardLazilyAndCaches(t *testing.T) {
+	var shardRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/go1.21/index.json" {
+			data, _ := json.Marshal(stdlibPackageIndex{Packages: []string{"fmt"}})
+			_, _ = w.Write(data)
+			return
+		}
+		shardRequests++
+		pkg := core.GoPackageEntry{
+			ImportPath: "fmt",
+			Functions: []*core.GoStdlibFunction{
+				{Name: "Sprintf", Returns: []*core.GoReturnValue{{Type: "string"}}},
+			},
+		}
+		data, _ := json.Marshal(pkg)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	loader, err := NewLazyGoStdlibLoader(context.Background(), server.URL, "1.21", dir, false, newGoVersionTestLogger())
+	require.NoError(t, err)
+
+	fn, err := loader.GetFunction("fmt", "Sprintf")
+	require.NoError(t, err)
+	require.NotNil(t, fn)
+	assert.Equal(t, "Sprintf", fn.Name)
+
+	// A second lookup must not re-fetch the shard.
+	_, err = loader.GetFunction("fmt", "Sprintf")
+	require.NoError(t, err)
+	assert.Equal(t, 1, shardRequests)
+
+	_, statErr := os.Stat(stdlibShardCachePath(dir, "1.21", "fmt"))
+	assert.NoError(t, statErr, "shard should be written to the on-disk cache")
+}
+
+func TestLazyGoStdlibLoader_GetFunction_Offline_NoCachedShard_ReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	data, _ := json.Marshal(stdlibPackageIndex{Packages: []string{"fmt"}})
+	require.NoError(t, os.MkdirAll(filepath.Dir(stdlibIndexCachePath(dir, "1.21")), 0o755))
+	require.NoError(t, os.WriteFile(stdlibIndexCachePath(dir, "1.21"), data, 0o600))
+
+	loader, err := NewLazyGoStdlibLoader(context.Background(), "http://127.0.0.1:0", "1.21", dir, true, newGoVersionTestLogger())
+	require.NoError(t, err)
+
+	_, err = loader.GetFunction("fmt", "Sprintf")
+	assert.Error(t, err)
+}
+
+func TestLazyGoStdlibLoader_GetFunction_MemoizesFailure(t *testing.T) {
+	var shardRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/go1.21/index.json" {
+			data, _ := json.Marshal(stdlibPackageIndex{Packages: []string{"fmt"}})
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(data)
+			return
+		}
+		shardRequests++
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	loader, err := NewLazyGoStdlibLoader(context.Background(), server.URL, "1.21", t.TempDir(), false, newGoVersionTestLogger())
+	require.NoError(t, err)
+
+	_, err1 := loader.GetFunction("fmt", "Sprintf")
+	_, err2 := loader.GetFunction("fmt", "Sprintf")
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+	assert.Equal(t, 1, shardRequests, "a failed shard fetch should be memoized, not retried")
+}
+
+func TestNewLazyGoStdlibLoader_CanceledContext_FallsBackToEmbeddedCore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := json.Marshal(stdlibPackageIndex{Packages: []string{"fmt", "os"}})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	loader, err := NewLazyGoStdlibLoader(ctx, server.URL, "1.21", t.TempDir(), false, newGoVersionTestLogger())
+	require.NoError(t, err, "a canceled context falls back to the embedded core manifest rather than failing outright")
+	assert.True(t, loader.ValidateStdlibImport("fmt"))
+}
+
+func TestLazyGoStdlibLoader_PackageCount_IncludesEmbeddedCore(t *testing.T) {
+	loader, err := NewLazyGoStdlibLoader(context.Background(), "http://127.0.0.1:0", "1.21", t.TempDir(), true, newGoVersionTestLogger())
+	require.NoError(t, err)
+	assert.Equal(t, len(embeddedCorePackages), loader.PackageCount())
+}
+
+func TestEmbeddedCorePackages_IncludesExpectedBaseline(t *testing.T) {
+	for _, pkg := range []string{"fmt", "os", "io", "net/http", "strings", "bytes", "errors", "context", "encoding/json"} {
+		assert.True(t, embeddedCorePackages[pkg], "expected %s in the embedded core manifest", pkg)
+	}
+}
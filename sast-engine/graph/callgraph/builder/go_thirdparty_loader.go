@@ -0,0 +1,257 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/golang"
+)
+
+// InitThirdPartyLoader indexes exported function signatures for every
+// dependency in the project's go.mod straight out of the local Go module
+// cache (GOMODCACHE), so callers into third-party packages get the same
+// signature/return-type enrichment InitGoStdlibLoader already provides for
+// the standard library.
+//
+// Unlike InitGoModuleProxyLoader, this never touches the network: it only
+// looks at modules `go mod download` has already placed on disk, under
+// {GOMODCACHE}/{escaped module path}@{version}. Missing or not-yet-downloaded
+// modules are skipped (logged at Progress level, not Warning — an
+// undownloaded dependency is normal, not an error).
+//
+// On success the loader is stored in reg.ThirdPartyLoader (mirroring
+// reg.StdlibLoader), and reg.ModuleVersions is populated from go.sum so
+// callers can surface a dependency's resolved version even when its source
+// isn't present locally to parse a signature from.
+func InitThirdPartyLoader(reg *core.GoModuleRegistry, projectPath string, logger *output.Logger) {
+	cacheDir := thirdPartyGoModCacheDir()
+	if cacheDir == "" {
+		logger.Progress("GOMODCACHE unavailable — skipping third-party module enrichment")
+		return
+	}
+
+	requires, err := resolution.ParseGoModRequires(projectPath)
+	if err != nil || len(requires) == 0 {
+		return
+	}
+
+	versions, err := resolution.ParseGoSumVersions(projectPath)
+	if err != nil {
+		logger.Warning("Failed to parse go.sum: %v", err)
+		versions = map[string]string{}
+	}
+	reg.ModuleVersions = versions
+
+	loader := newGoModCacheLoader()
+	for _, req := range requires {
+		version := req.Version
+		if sumVersion, ok := versions[req.Path]; ok {
+			version = sumVersion
+		}
+
+		moduleDir := filepath.Join(cacheDir, escapeGoproxyPath(req.Path)+"@"+version)
+		if info, statErr := os.Stat(moduleDir); statErr != nil || !info.IsDir() {
+			logger.Progress("%s@%s not found in GOMODCACHE — skipping", req.Path, version)
+			continue
+		}
+		loader.indexModule(req.Path, version, moduleDir)
+	}
+
+	if loader.ModuleCount() == 0 {
+		logger.Progress("No third-party modules found in GOMODCACHE — skipping module enrichment")
+		return
+	}
+
+	logger.Progress("Indexed %d third-party package(s) from GOMODCACHE", loader.ModuleCount())
+	reg.ThirdPartyLoader = loader
+}
+
+// thirdPartyGoModCacheDir resolves the local Go module cache directory,
+// following the same precedence the `go` toolchain itself uses: an explicit
+// GOMODCACHE, falling back to the first entry of GOPATH plus "pkg/mod",
+// falling back to "{home}/go/pkg/mod". Returns "" when none of these can be
+// determined.
+func thirdPartyGoModCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	if gopath := os.Getenv("GOPATH"); gopath != "" {
+		first := strings.Split(gopath, string(os.PathListSeparator))[0]
+		if first != "" {
+			return filepath.Join(first, "pkg", "mod")
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, "go", "pkg", "mod")
+}
+
+// goModCachePackage holds the exported functions indexed for one package
+// import path, along with the module version they were parsed from.
+type goModCachePackage struct {
+	version   string
+	functions map[string]*core.GoStdlibFunction
+}
+
+// goModCacheLoader implements core.ThirdPartyLoader by indexing exported
+// function signatures parsed directly out of a project's local GOMODCACHE.
+// It mirrors cachedGoManifestLoader's in-memory, import-path-keyed shape,
+// just sourced from locally downloaded module source instead of a
+// pre-built manifest.
+type goModCacheLoader struct {
+	packages map[string]*goModCachePackage
+}
+
+func newGoModCacheLoader() *goModCacheLoader {
+	return &goModCacheLoader{packages: make(map[string]*goModCachePackage)}
+}
+
+// indexModule walks modulePath's extracted source tree under moduleDir,
+// resolving every nested package to its import path via
+// resolution.BuildGoModuleRegistry (the same helper indexModulePackages uses
+// for proxy-fetched modules), and indexes each package's exported top-level
+// functions.
+func (l *goModCacheLoader) indexModule(modulePath, version, moduleDir string) {
+	reg, err := resolution.BuildGoModuleRegistry(moduleDir)
+	if err != nil {
+		// The dependency's own go.mod may be malformed or absent (rare) —
+		// fall back to indexing just the module root so callers still get
+		// partial coverage.
+		l.indexPackageDir(modulePath, version, moduleDir)
+		return
+	}
+	for nestedDir, importPath := range reg.DirToImport {
+		importPath = strings.Replace(importPath, reg.ModulePath, modulePath, 1)
+		l.indexPackageDir(importPath, version, nestedDir)
+	}
+}
+
+// indexPackageDir parses every non-test .go file directly inside dir and
+// records importPath's exported functions, if any were found. See
+// indexPackageExportedFunctions (go_thirdparty_proxy_loader.go) for the
+// actual parse, shared with the GOPROXY-backed loader.
+func (l *goModCacheLoader) indexPackageDir(importPath, version, dir string) {
+	functions := indexPackageExportedFunctions(dir)
+	if len(functions) == 0 {
+		return
+	}
+	l.packages[importPath] = &goModCachePackage{version: version, functions: functions}
+}
+
+// ValidateModuleImport reports whether importPath was indexed from a
+// dependency's GOMODCACHE source.
+func (l *goModCacheLoader) ValidateModuleImport(importPath string) bool {
+	_, ok := l.packages[importPath]
+	return ok
+}
+
+// GetFunction looks up a function by name within importPath's indexed package.
+func (l *goModCacheLoader) GetFunction(importPath, funcName string) (*core.GoStdlibFunction, error) {
+	pkg, ok := l.packages[importPath]
+	if !ok {
+		return nil, fmt.Errorf("package %q not indexed from GOMODCACHE", importPath)
+	}
+	fn, ok := pkg.functions[funcName]
+	if !ok {
+		return nil, fmt.Errorf("function %s.%s not indexed from GOMODCACHE", importPath, funcName)
+	}
+	return fn, nil
+}
+
+// GetType always errors: only function signatures are currently parsed out
+// of GOMODCACHE source, not type declarations.
+func (l *goModCacheLoader) GetType(importPath, typeName string) (*core.GoStdlibType, error) {
+	return nil, fmt.Errorf("type %s.%s not available from the GOMODCACHE loader", importPath, typeName)
+}
+
+// ModuleCount returns the number of packages indexed across all dependencies.
+func (l *goModCacheLoader) ModuleCount() int {
+	return len(l.packages)
+}
+
+// parseExportedGoFunctions parses a single Go source file with the
+// tree-sitter Go grammar and extracts every exported, non-method top-level
+// function's signature and return types.
+func parseExportedGoFunctions(source []byte) map[string]*core.GoStdlibFunction {
+	parser := sitter.NewParser()
+	parser.SetLanguage(golang.GetLanguage())
+	defer parser.Close()
+
+	tree, err := parser.ParseCtx(context.Background(), nil, source)
+	if err != nil {
+		return nil
+	}
+	defer tree.Close()
+
+	functions := make(map[string]*core.GoStdlibFunction)
+	root := tree.RootNode()
+	for i := 0; i < int(root.ChildCount()); i++ {
+		child := root.Child(i)
+		if child.Type() != "function_declaration" {
+			continue
+		}
+		nameNode := child.ChildByFieldName("name")
+		if nameNode == nil {
+			continue
+		}
+		name := nameNode.Content(source)
+		if !token.IsExported(name) {
+			continue
+		}
+		functions[name] = &core.GoStdlibFunction{
+			Name:      name,
+			Signature: goFunctionSignature(child, source),
+			Returns:   goFunctionReturns(child, source),
+		}
+	}
+	return functions
+}
+
+// goFunctionSignature reconstructs a function's signature text — everything
+// up to, but excluding, its body — from the original source span. This is
+// simpler and more faithful to the dependency's own formatting than
+// rebuilding the signature from the parsed parameter/result nodes.
+func goFunctionSignature(funcNode *sitter.Node, source []byte) string {
+	end := funcNode.EndByte()
+	if body := funcNode.ChildByFieldName("body"); body != nil {
+		end = body.StartByte()
+	}
+	return strings.TrimSpace(string(source[funcNode.StartByte():end]))
+}
+
+// goFunctionReturns extracts a function's result types. The Go grammar
+// represents a single unnamed result as a bare type node and multiple (or
+// named) results as a parameter_list of parameter_declaration nodes.
+func goFunctionReturns(funcNode *sitter.Node, source []byte) []*core.GoReturnValue {
+	result := funcNode.ChildByFieldName("result")
+	if result == nil {
+		return nil
+	}
+	if result.Type() != "parameter_list" {
+		return []*core.GoReturnValue{{Type: result.Content(source)}}
+	}
+
+	var returns []*core.GoReturnValue
+	for i := 0; i < int(result.ChildCount()); i++ {
+		param := result.Child(i)
+		if param.Type() != "parameter_declaration" {
+			continue
+		}
+		typeNode := param.ChildByFieldName("type")
+		if typeNode == nil {
+			continue
+		}
+		returns = append(returns, &core.GoReturnValue{Type: typeNode.Content(source)})
+	}
+	return returns
+}
@@ -0,0 +1,134 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeModCacheFile writes content to relPath inside a fake GOMODCACHE
+// directory, creating parent directories as needed.
+func writeModCacheFile(t *testing.T, dir, relPath, content string) {
+	t.Helper()
+	full := filepath.Join(dir, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+	require.NoError(t, os.WriteFile(full, []byte(content), 0o600))
+}
+
+func TestThirdPartyGoModCacheDir_ExplicitEnv(t *testing.T) {
+	t.Setenv("GOMODCACHE", "/custom/mod/cache")
+	assert.Equal(t, "/custom/mod/cache", thirdPartyGoModCacheDir())
+}
+
+func TestThirdPartyGoModCacheDir_FallsBackToGOPATH(t *testing.T) {
+	t.Setenv("GOMODCACHE", "")
+	t.Setenv("GOPATH", "/home/dev/go")
+	assert.Equal(t, filepath.Join("/home/dev/go", "pkg", "mod"), thirdPartyGoModCacheDir())
+}
+
+func TestParseExportedGoFunctions_SkipsUnexportedAndMethods(t *testing.T) {
+	source := []byte(`package pq
+
+func Open(name string) (*DB, error) {
+	return nil, nil
+}
+
+func unexported() {}
+
+func (d *DB) Close() error {
+	return nil
+}
+`)
+
+	functions := parseExportedGoFunctions(source)
+	require.Contains(t, functions, "Open")
+	assert.NotContains(t, functions, "unexported")
+	assert.NotContains(t, functions, "Close")
+
+	open := functions["Open"]
+	assert.Equal(t, "func Open(name string) (*DB, error)", open.Signature)
+	require.Len(t, open.Returns, 2)
+	assert.Equal(t, "*DB", open.Returns[0].Type)
+	assert.Equal(t, "error", open.Returns[1].Type)
+}
+
+func TestParseExportedGoFunctions_SingleUnnamedReturn(t *testing.T) {
+	source := []byte(`package pq
+
+func Version() string {
+	return "1.0"
+}
+`)
+
+	functions := parseExportedGoFunctions(source)
+	require.Contains(t, functions, "Version")
+	require.Len(t, functions["Version"].Returns, 1)
+	assert.Equal(t, "string", functions["Version"].Returns[0].Type)
+}
+
+func TestParseExportedGoFunctions_NoReturn(t *testing.T) {
+	source := []byte(`package pq
+
+func Init() {}
+`)
+
+	functions := parseExportedGoFunctions(source)
+	require.Contains(t, functions, "Init")
+	assert.Empty(t, functions["Init"].Returns)
+}
+
+func TestInitThirdPartyLoader_IndexesDownloadedModule(t *testing.T) {
+	cacheDir := t.TempDir()
+	writeModCacheFile(t, cacheDir, "github.com/lib/pq@v1.10.0/go.mod", "module github.com/lib/pq\n\ngo 1.16\n")
+	writeModCacheFile(t, cacheDir, "github.com/lib/pq@v1.10.0/conn.go", `package pq
+
+func Open(name string) (*DB, error) {
+	return nil, nil
+}
+`)
+	t.Setenv("GOMODCACHE", cacheDir)
+
+	projectDir := t.TempDir()
+	writeTempFile(t, projectDir, "go.mod", "module github.com/example/app\n\ngo 1.21\n\nrequire github.com/lib/pq v1.9.0\n")
+	writeTempFile(t, projectDir, "go.sum", "github.com/lib/pq v1.10.0 h1:abc=\ngithub.com/lib/pq v1.10.0/go.mod h1:def=\n")
+
+	reg := core.NewGoModuleRegistry()
+	InitThirdPartyLoader(reg, projectDir, newGoVersionTestLogger())
+
+	require.NotNil(t, reg.ThirdPartyLoader, "go.sum's resolved v1.10.0 should be preferred over go.mod's v1.9.0")
+	assert.True(t, reg.ThirdPartyLoader.ValidateModuleImport("github.com/lib/pq"))
+
+	fn, err := reg.ThirdPartyLoader.GetFunction("github.com/lib/pq", "Open")
+	require.NoError(t, err)
+	assert.Equal(t, "func Open(name string) (*DB, error)", fn.Signature)
+
+	assert.Equal(t, "v1.10.0", reg.ModuleVersions["github.com/lib/pq"])
+}
+
+func TestInitThirdPartyLoader_SkipsUndownloadedModule(t *testing.T) {
+	t.Setenv("GOMODCACHE", t.TempDir())
+
+	projectDir := t.TempDir()
+	writeTempFile(t, projectDir, "go.mod", "module github.com/example/app\n\ngo 1.21\n\nrequire github.com/lib/pq v1.10.0\n")
+
+	reg := core.NewGoModuleRegistry()
+	InitThirdPartyLoader(reg, projectDir, newGoVersionTestLogger())
+
+	assert.Nil(t, reg.ThirdPartyLoader)
+}
+
+func TestInitThirdPartyLoader_NoRequireDirectives(t *testing.T) {
+	t.Setenv("GOMODCACHE", t.TempDir())
+
+	projectDir := t.TempDir()
+	writeTempFile(t, projectDir, "go.mod", "module github.com/example/app\n\ngo 1.21\n")
+
+	reg := core.NewGoModuleRegistry()
+	InitThirdPartyLoader(reg, projectDir, newGoVersionTestLogger())
+
+	assert.Nil(t, reg.ThirdPartyLoader)
+}
@@ -0,0 +1,237 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// InitGoThirdPartyProxyLoader builds a core.GoThirdPartyLoader backed by the
+// Go module proxy (see go_module_proxy.go's GOPROXY client), indexed by the
+// MVS-resolved versions already recorded in go.sum — unlike InitThirdPartyLoader
+// (GOMODCACHE-only, one version per run), callers can ask this loader about
+// whichever exact module@version a given call site's go.sum entry pins, and it
+// fetches + parses signatures for that version lazily, on first access.
+//
+// On success the loader is stored in reg.GoThirdPartyLoader. There is no
+// failure return: a project with no go.sum, or one GOPROXY=off disables
+// entirely, simply gets an empty loader that answers every GetFunction call
+// with "not found" — the same graceful-degradation shape InitGoStdlibLoader
+// uses when a manifest can't be loaded.
+func InitGoThirdPartyProxyLoader(reg *core.GoModuleRegistry, projectPath string, logger *output.Logger) {
+	cacheDir := filepath.Join(projectPath, ".codepathfinder", "cache", "proxy-modules")
+
+	versions, err := resolution.ParseGoSumVersions(projectPath)
+	if err != nil {
+		logger.Warning("Failed to parse go.sum: %v", err)
+		versions = map[string]string{}
+	}
+	reg.ModuleVersions = versions
+
+	reg.GoThirdPartyLoader = newGoProxyThirdPartyLoader(cacheDir, logger)
+}
+
+// goProxyThirdPartyLoader implements core.GoThirdPartyLoader by fetching
+// third-party module source via GOPROXY (see fetchModuleViaProxy) and lazily
+// parsing each package's exported function signatures with the same
+// tree-sitter AST pass InitThirdPartyLoader uses for GOMODCACHE sources (see
+// parseExportedGoFunctions / indexPackageExportedFunctions).
+//
+// GetFunction's four-argument shape — (modulePath, version, pkgPath,
+// funcName) — mirrors go.sum's own module@version identity. That's the key
+// difference from core.ThirdPartyLoader (import-path only, one version
+// assumed per run): this loader can answer for whichever version MVS
+// actually resolved for a given dependency edge, which matters once two
+// modules in the graph pin different versions of a shared transitive
+// dependency.
+//
+// Disk caching is inherited from fetchModuleViaProxy: a module@version's
+// extracted source is written once under cacheDir and reused by every later
+// run with no network round-trip. Parsed function signatures are memoized
+// in-process only — re-parsing already-cached source from disk is cheap
+// enough that a second on-disk cache layer for signatures isn't worth the
+// invalidation complexity.
+type goProxyThirdPartyLoader struct {
+	cacheDir string
+	logger   *output.Logger
+
+	mu         sync.Mutex
+	moduleDirs map[string]string                            // "module@version" -> extracted source dir
+	packages   map[string]map[string]*core.GoStdlibFunction // "module@version/pkgPath" -> exported functions
+}
+
+func newGoProxyThirdPartyLoader(cacheDir string, logger *output.Logger) *goProxyThirdPartyLoader {
+	return &goProxyThirdPartyLoader{
+		cacheDir:   cacheDir,
+		logger:     logger,
+		moduleDirs: make(map[string]string),
+		packages:   make(map[string]map[string]*core.GoStdlibFunction),
+	}
+}
+
+// GetFunction looks up funcName within pkgPath's exported functions for
+// modulePath@version, fetching and parsing the module via GOPROXY on first
+// access to that (modulePath, version, pkgPath) triple.
+//
+// modulePath values matched by GOPRIVATE are refused outright: this loader
+// has no VCS fallback (mirroring resolveGoproxyList's handling of "direct"),
+// so a private module can never be fetched through a public proxy here.
+func (l *goProxyThirdPartyLoader) GetFunction(modulePath, version, pkgPath, funcName string) (*core.GoStdlibFunction, error) {
+	if isGoPrivateModule(modulePath) {
+		return nil, fmt.Errorf("%s is matched by GOPRIVATE — no proxy fetch attempted", modulePath)
+	}
+
+	functions, err := l.packageFunctions(modulePath, version, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := functions[funcName]
+	if !ok {
+		return nil, fmt.Errorf("function %s.%s not found in %s@%s", pkgPath, funcName, modulePath, version)
+	}
+	return fn, nil
+}
+
+// packageFunctions returns pkgPath's exported functions for modulePath@version,
+// fetching the module via GOPROXY and parsing the package's source on first
+// access, then memoizing the result for later calls.
+func (l *goProxyThirdPartyLoader) packageFunctions(modulePath, version, pkgPath string) (map[string]*core.GoStdlibFunction, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cacheKey := modulePath + "@" + version + "/" + pkgPath
+	if functions, ok := l.packages[cacheKey]; ok {
+		return functions, nil
+	}
+
+	moduleDir, err := l.fetchModuleDir(modulePath, version)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgDir, err := resolveThirdPartyPackageDir(moduleDir, modulePath, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	functions := indexPackageExportedFunctions(pkgDir)
+	l.packages[cacheKey] = functions
+	return functions, nil
+}
+
+// fetchModuleDir returns modulePath@version's extracted source directory,
+// fetching it via GOPROXY (or reusing fetchModuleViaProxy's on-disk cache) on
+// first access and memoizing the directory for later calls.
+func (l *goProxyThirdPartyLoader) fetchModuleDir(modulePath, version string) (string, error) {
+	key := modulePath + "@" + version
+	if dir, ok := l.moduleDirs[key]; ok {
+		return dir, nil
+	}
+
+	proxies := resolveGoproxyList()
+	if len(proxies) == 0 {
+		return "", fmt.Errorf("GOPROXY=off — cannot fetch %s", key)
+	}
+
+	dir, err := fetchModuleViaProxy(proxies, modulePath, version, l.cacheDir)
+	if err != nil {
+		return "", err
+	}
+	l.moduleDirs[key] = dir
+	l.logger.Progress("Fetched %s via GOPROXY for third-party signature lookup", key)
+	return dir, nil
+}
+
+// resolveThirdPartyPackageDir resolves pkgPath (e.g.
+// "github.com/gin-gonic/gin") to its on-disk directory within moduleDir,
+// modulePath@version's extracted source root, using the same DirToImport
+// resolution indexModule and indexModulePackages rely on for GOMODCACHE- and
+// GOPROXY-fetched sources.
+func resolveThirdPartyPackageDir(moduleDir, modulePath, pkgPath string) (string, error) {
+	reg, err := resolution.BuildGoModuleRegistry(moduleDir)
+	if err != nil {
+		if pkgPath == modulePath {
+			return moduleDir, nil
+		}
+		return "", fmt.Errorf("failed to resolve packages under %s: %w", moduleDir, err)
+	}
+	for nestedDir, importPath := range reg.DirToImport {
+		importPath = strings.Replace(importPath, reg.ModulePath, modulePath, 1)
+		if importPath == pkgPath {
+			return nestedDir, nil
+		}
+	}
+	if pkgPath == modulePath {
+		return moduleDir, nil
+	}
+	return "", fmt.Errorf("package %s not found in %s", pkgPath, modulePath)
+}
+
+// indexPackageExportedFunctions parses every non-test .go file directly
+// inside dir and returns its exported top-level functions, keyed by name.
+// Shared by goModCacheLoader (GOMODCACHE-backed) and goProxyThirdPartyLoader
+// (GOPROXY-backed) — both ultimately want "exported functions declared
+// directly in this directory", just sourced from different places on disk.
+func indexPackageExportedFunctions(dir string) map[string]*core.GoStdlibFunction {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	functions := make(map[string]*core.GoStdlibFunction)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		source, readErr := os.ReadFile(filepath.Join(dir, name))
+		if readErr != nil {
+			continue
+		}
+		for fnName, fn := range parseExportedGoFunctions(source) {
+			functions[fnName] = fn
+		}
+	}
+	return functions
+}
+
+// isGoPrivateModule reports whether modulePath matches any pattern in
+// GOPRIVATE — a comma-separated list of glob patterns, per `go help
+// goproxy`. A GOPRIVATE-matched module must never be proxied or cached by
+// this loader; since it has no VCS fallback (see resolveGoproxyList), the
+// only correct behaviour is to refuse it outright.
+//
+// GONOSUMCHECK (the pre-GOSUMDB env var that disabled checksum-database
+// verification) has no effect here: this loader never verifies a fetched
+// module's hash against any sum database in the first place — it trusts the
+// proxy's response the same way InitGoModuleProxyLoader already does — so
+// there is nothing for GONOSUMCHECK to disable.
+func isGoPrivateModule(modulePath string) bool {
+	raw := os.Getenv("GOPRIVATE")
+	if raw == "" {
+		return false
+	}
+	for _, pattern := range strings.Split(raw, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if matched, _ := path.Match(pattern, modulePath); matched {
+			return true
+		}
+		// Patterns without wildcards commonly name a path prefix (e.g.
+		// "corp.example.com/internal"), matching that module and everything
+		// nested under it — mirroring the real `go` tool's glob semantics.
+		if !strings.ContainsAny(pattern, "*?[") && strings.HasPrefix(modulePath, pattern) {
+			return true
+		}
+	}
+	return false
+}
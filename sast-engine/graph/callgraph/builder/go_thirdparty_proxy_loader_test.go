@@ -0,0 +1,147 @@
+package builder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoProxyThirdPartyLoader_GetFunction_FetchesAndParses(t *testing.T) {
+	zipData := newTestProxyZip(t, "github.com/gin-gonic/gin@v1.9.0", map[string]string{
+		"go.mod": "module github.com/gin-gonic/gin\n\ngo 1.18\n",
+		"gin.go": "package gin\n\nfunc Default() *Engine { return nil }\n\ntype Engine struct{}\n",
+	})
+	server := newTestProxyServer(t, "github.com/gin-gonic/gin", "v1.9.0", zipData)
+	defer server.Close()
+
+	original := goModuleProxyHTTPClient
+	t.Cleanup(func() { goModuleProxyHTTPClient = original })
+	goModuleProxyHTTPClient = server.Client()
+
+	t.Setenv("GOPROXY", server.URL)
+	t.Setenv("GOPRIVATE", "")
+
+	loader := newGoProxyThirdPartyLoader(t.TempDir(), newGoVersionTestLogger())
+	fn, err := loader.GetFunction("github.com/gin-gonic/gin", "v1.9.0", "github.com/gin-gonic/gin", "Default")
+	require.NoError(t, err)
+	assert.Equal(t, "Default", fn.Name)
+	assert.Equal(t, "*Engine", fn.Returns[0].Type)
+}
+
+func TestGoProxyThirdPartyLoader_GetFunction_MemoizesAcrossCalls(t *testing.T) {
+	zipData := newTestProxyZip(t, "github.com/sirupsen/logrus@v1.9.3", map[string]string{
+		"go.mod":    "module github.com/sirupsen/logrus\n\ngo 1.18\n",
+		"logrus.go": "package logrus\n\nfunc New() *Logger { return nil }\n\ntype Logger struct{}\n",
+	})
+	proxy := newTestProxyServer(t, "github.com/sirupsen/logrus", "v1.9.3", zipData)
+	defer proxy.Close()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		proxy.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	original := goModuleProxyHTTPClient
+	t.Cleanup(func() { goModuleProxyHTTPClient = original })
+	goModuleProxyHTTPClient = server.Client()
+	t.Setenv("GOPROXY", server.URL)
+
+	loader := newGoProxyThirdPartyLoader(t.TempDir(), newGoVersionTestLogger())
+	_, err := loader.GetFunction("github.com/sirupsen/logrus", "v1.9.3", "github.com/sirupsen/logrus", "New")
+	require.NoError(t, err)
+	firstCalls := calls
+	firstModuleDirs := len(loader.moduleDirs)
+
+	_, err = loader.GetFunction("github.com/sirupsen/logrus", "v1.9.3", "github.com/sirupsen/logrus", "New")
+	require.NoError(t, err)
+	assert.Equal(t, firstModuleDirs, len(loader.moduleDirs), "second lookup must reuse the memoized module dir, not re-fetch")
+	assert.Equal(t, firstCalls, calls, "second lookup must not hit the proxy again")
+}
+
+func TestGoProxyThirdPartyLoader_GetFunction_FunctionNotFound(t *testing.T) {
+	zipData := newTestProxyZip(t, "github.com/example/pkg@v1.0.0", map[string]string{
+		"go.mod": "module github.com/example/pkg\n\ngo 1.18\n",
+		"pkg.go": "package pkg\n\nfunc Foo() {}\n",
+	})
+	server := newTestProxyServer(t, "github.com/example/pkg", "v1.0.0", zipData)
+	defer server.Close()
+
+	original := goModuleProxyHTTPClient
+	t.Cleanup(func() { goModuleProxyHTTPClient = original })
+	goModuleProxyHTTPClient = server.Client()
+	t.Setenv("GOPROXY", server.URL)
+
+	loader := newGoProxyThirdPartyLoader(t.TempDir(), newGoVersionTestLogger())
+	_, err := loader.GetFunction("github.com/example/pkg", "v1.0.0", "github.com/example/pkg", "Bar")
+	assert.Error(t, err)
+}
+
+func TestGoProxyThirdPartyLoader_GetFunction_GoproxyOff(t *testing.T) {
+	t.Setenv("GOPROXY", "off")
+	loader := newGoProxyThirdPartyLoader(t.TempDir(), newGoVersionTestLogger())
+	_, err := loader.GetFunction("github.com/example/pkg", "v1.0.0", "github.com/example/pkg", "Foo")
+	assert.Error(t, err)
+}
+
+func TestGoProxyThirdPartyLoader_GetFunction_GoPrivateModuleRefused(t *testing.T) {
+	t.Setenv("GOPRIVATE", "corp.example.com/*")
+	loader := newGoProxyThirdPartyLoader(t.TempDir(), newGoVersionTestLogger())
+	_, err := loader.GetFunction("corp.example.com/internal/tool", "v1.0.0", "corp.example.com/internal/tool", "Run")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "GOPRIVATE")
+}
+
+func TestIsGoPrivateModule(t *testing.T) {
+	t.Setenv("GOPRIVATE", "corp.example.com/*,internal.example.org")
+
+	assert.True(t, isGoPrivateModule("corp.example.com/foo"))
+	assert.True(t, isGoPrivateModule("internal.example.org"))
+	assert.True(t, isGoPrivateModule("internal.example.org/sub/pkg"))
+	assert.False(t, isGoPrivateModule("github.com/gin-gonic/gin"))
+}
+
+func TestIsGoPrivateModule_Unset(t *testing.T) {
+	t.Setenv("GOPRIVATE", "")
+	assert.False(t, isGoPrivateModule("github.com/gin-gonic/gin"))
+}
+
+func TestInitGoThirdPartyProxyLoader_SetsLoaderAndModuleVersions(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/app\n\ngo 1.21\n\nrequire github.com/gin-gonic/gin v1.9.0\n")
+	writeTempFile(t, dir, "go.sum", "github.com/gin-gonic/gin v1.9.0 h1:abcdef=\ngithub.com/gin-gonic/gin v1.9.0/go.mod h1:ghijkl=\n")
+
+	reg := newTestRegistry(nil)
+	logger := newGoVersionTestLogger()
+	InitGoThirdPartyProxyLoader(reg, dir, logger)
+
+	require.NotNil(t, reg.GoThirdPartyLoader)
+	assert.Equal(t, "v1.9.0", reg.ModuleVersions["github.com/gin-gonic/gin"])
+}
+
+func TestResolveThirdPartyPackageDir_NestedPackage(t *testing.T) {
+	zipData := newTestProxyZip(t, "github.com/example/pkg@v1.0.0", map[string]string{
+		"go.mod":     "module github.com/example/pkg\n\ngo 1.18\n",
+		"sub/sub.go": "package sub\n\nfunc Foo() {}\n",
+	})
+	server := newTestProxyServer(t, "github.com/example/pkg", "v1.0.0", zipData)
+	defer server.Close()
+
+	original := goModuleProxyHTTPClient
+	t.Cleanup(func() { goModuleProxyHTTPClient = original })
+	goModuleProxyHTTPClient = server.Client()
+
+	cacheDir := t.TempDir()
+	moduleDir, err := fetchModuleViaProxy([]string{server.URL}, "github.com/example/pkg", "v1.0.0", cacheDir)
+	require.NoError(t, err)
+
+	pkgDir, err := resolveThirdPartyPackageDir(moduleDir, "github.com/example/pkg", "github.com/example/pkg/sub")
+	require.NoError(t, err)
+	_, statErr := os.Stat(filepath.Join(pkgDir, "sub.go"))
+	assert.NoError(t, statErr)
+}
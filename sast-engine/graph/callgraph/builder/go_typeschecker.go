@@ -0,0 +1,329 @@
+package builder
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// InitGoTypesChecker wires up a project's package directories so
+// GoTypeInferenceEngine.GetReturnType/GetExpressionType can fall back to the
+// standard go/types checker when neither a local scope binding nor the
+// StdlibLoader has an answer — the authoritative source for user code, since
+// it correctly handles anonymous structs, embedded methods, and type aliases
+// the hand-rolled stdlib manifest approach can't reason about.
+//
+// Nothing is parsed or type-checked here: only the package-path → directory
+// mapping (from resolution.BuildGoModuleRegistry) is resolved up front. Each
+// package is parsed and checked lazily, on its first FuncReturnType or
+// ExpressionType call, and the result cached — most projects only ever need
+// a handful of their packages checked per run.
+func InitGoTypesChecker(reg *core.GoModuleRegistry, projectPath string, logger *output.Logger) {
+	modReg, err := resolution.BuildGoModuleRegistry(projectPath)
+	if err != nil {
+		logger.Progress("go/types checker unavailable — failed to resolve module layout: %v", err)
+		return
+	}
+
+	dirs := make(map[string]string, len(modReg.DirToImport))
+	for dir, importPath := range modReg.DirToImport {
+		dirs[importPath] = dir
+	}
+
+	reg.TypesChecker = &goTypesChecker{
+		dirs:     dirs,
+		fset:     token.NewFileSet(),
+		packages: make(map[string]*checkedGoPackage),
+	}
+}
+
+// checkedGoPackage is the result of type-checking a single package: its
+// *types.Package (for Scope().Lookup by function name) and the *types.Info
+// recording every expression's resolved type (for ExpressionType lookups).
+type checkedGoPackage struct {
+	pkg  *types.Package
+	info *types.Info
+	// files holds every parsed *ast.File of the package, kept so
+	// ExpressionType can search a specific function's body across every file
+	// in the package without re-parsing.
+	files []*ast.File
+}
+
+// goTypesChecker implements core.TypesChecker by lazily parsing and
+// type-checking a project's own packages with go/parser and go/types.
+// Checking errors (a package that doesn't compile, an unresolvable import)
+// are expected on real-world code and are not fatal: the affected package is
+// simply cached as uncheckable, and callers fall through to the stdlib
+// fallback or give up, same as if TypesChecker weren't configured at all.
+type goTypesChecker struct {
+	dirs map[string]string // import path -> directory
+	fset *token.FileSet
+
+	mu       sync.Mutex
+	packages map[string]*checkedGoPackage // import path -> checked package, or nil if uncheckable
+}
+
+// FuncReturnType implements core.TypesChecker.
+func (c *goTypesChecker) FuncReturnType(pkgPath, funcName string) (*core.TypeInfo, bool) {
+	checked := c.checkPackage(pkgPath)
+	if checked == nil {
+		return nil, false
+	}
+	obj := checked.pkg.Scope().Lookup(funcName)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok {
+		return nil, false
+	}
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		typeFQN := goTypeToFQN(results.At(i).Type(), pkgPath)
+		if typeFQN == "" || typeFQN == "builtin.error" {
+			continue
+		}
+		return &core.TypeInfo{TypeFQN: typeFQN, Confidence: 1.0, Source: "go/types"}, true
+	}
+	return nil, false
+}
+
+// ExpressionType implements core.TypesChecker: it finds funcName's
+// declaration across pkgPath's parsed files, then walks its body for an
+// expression at source line whose source text (via types.ExprString)
+// matches expr, returning the type go/types resolved for it.
+//
+// line disambiguates a body where the same expression text occurs more than
+// once with different resolved types (e.g. a name shadowed by a
+// differently-typed variable in an inner block) — a node is only a candidate when
+// both its text and its line match, rather than taking whichever occurrence
+// ast.Inspect happens to reach first.
+func (c *goTypesChecker) ExpressionType(pkgPath, funcName, expr string, line int) (*core.TypeInfo, bool) {
+	checked := c.checkPackage(pkgPath)
+	if checked == nil {
+		return nil, false
+	}
+
+	decl := findFuncDecl(checked.files, funcName)
+	if decl == nil || decl.Body == nil {
+		return nil, false
+	}
+
+	var found types.Type
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		e, ok := n.(ast.Expr)
+		if !ok {
+			return true
+		}
+		if types.ExprString(e) != expr {
+			return true
+		}
+		if c.fset.Position(e.Pos()).Line != line {
+			return true
+		}
+		if tv, ok := checked.info.Types[e]; ok {
+			found = tv.Type
+			return false
+		}
+		return true
+	})
+	if found == nil {
+		return nil, false
+	}
+	typeFQN := goTypeToFQN(found, pkgPath)
+	if typeFQN == "" {
+		return nil, false
+	}
+	return &core.TypeInfo{TypeFQN: typeFQN, Confidence: 1.0, Source: "go/types"}, true
+}
+
+// MethodSet implements core.TypesChecker: it returns the exported method
+// names declared on typeName's method set (value and pointer receivers
+// alike, via a *types.Pointer method set so both are included) within
+// pkgPath. Used by GoTypeInferenceEngine to index a concrete type against
+// the interfaces it satisfies.
+func (c *goTypesChecker) MethodSet(pkgPath, typeName string) ([]string, bool) {
+	checked := c.checkPackage(pkgPath)
+	if checked == nil {
+		return nil, false
+	}
+	obj := checked.pkg.Scope().Lookup(typeName)
+	typeName2, ok := obj.(*types.TypeName)
+	if !ok {
+		return nil, false
+	}
+	named, ok := typeName2.Type().(*types.Named)
+	if !ok {
+		return nil, false
+	}
+
+	methodSet := types.NewMethodSet(types.NewPointer(named))
+	methods := make([]string, 0, methodSet.Len())
+	for i := 0; i < methodSet.Len(); i++ {
+		fn := methodSet.At(i).Obj()
+		if fn.Exported() {
+			methods = append(methods, fn.Name())
+		}
+	}
+	sort.Strings(methods)
+	return methods, true
+}
+
+// Interfaces implements core.TypesChecker: it returns every interface type
+// declared at pkgPath's package scope, each mapped to its exported method
+// names.
+func (c *goTypesChecker) Interfaces(pkgPath string) map[string][]string {
+	checked := c.checkPackage(pkgPath)
+	if checked == nil {
+		return nil
+	}
+
+	scope := checked.pkg.Scope()
+	ifaces := make(map[string][]string)
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		iface, ok := typeName.Type().Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+
+		methods := make([]string, 0, iface.NumExplicitMethods())
+		for i := 0; i < iface.NumExplicitMethods(); i++ {
+			m := iface.ExplicitMethod(i)
+			if m.Exported() {
+				methods = append(methods, m.Name())
+			}
+		}
+		sort.Strings(methods)
+		ifaces[pkgPath+"."+name] = methods
+	}
+	return ifaces
+}
+
+// checkPackage returns pkgPath's checked package, parsing and type-checking
+// it on first request and caching the outcome (including failure, as a nil
+// entry) for every call after.
+func (c *goTypesChecker) checkPackage(pkgPath string) *checkedGoPackage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if checked, done := c.packages[pkgPath]; done {
+		return checked
+	}
+
+	dir, ok := c.dirs[pkgPath]
+	if !ok {
+		c.packages[pkgPath] = nil
+		return nil
+	}
+
+	astPkgs, err := parser.ParseDir(c.fset, dir, nonTestGoFile, parser.AllErrors)
+	if err != nil || len(astPkgs) == 0 {
+		c.packages[pkgPath] = nil
+		return nil
+	}
+
+	var files []*ast.File
+	for _, astPkg := range astPkgs {
+		for _, f := range astPkg.Files {
+			files = append(files, f)
+		}
+		break // a directory holds exactly one non-test package
+	}
+
+	info := &types.Info{
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+		Types: make(map[ast.Expr]types.TypeAndValue),
+	}
+	config := &types.Config{
+		Importer: importer.ForCompiler(c.fset, "source", nil),
+		Error:    func(error) {}, // best-effort: keep checking past the first error
+	}
+	pkg, _ := config.Check(pkgPath, c.fset, files, info)
+	if pkg == nil {
+		c.packages[pkgPath] = nil
+		return nil
+	}
+
+	checked := &checkedGoPackage{pkg: pkg, info: info, files: files}
+	c.packages[pkgPath] = checked
+	return checked
+}
+
+// nonTestGoFile excludes _test.go files from parser.ParseDir — type-checking
+// a package's tests isn't useful for inferring its exported API's types, and
+// test files often import test-only dependencies that complicate checking.
+func nonTestGoFile(info fs.FileInfo) bool {
+	return !strings.HasSuffix(info.Name(), "_test.go")
+}
+
+// findFuncDecl locates funcName's top-level function declaration (a method,
+// if funcName contains no receiver info, is not matched — ExpressionType
+// only supports plain functions today).
+func findFuncDecl(files []*ast.File, funcName string) *ast.FuncDecl {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if ok && fn.Recv == nil && fn.Name.Name == funcName {
+				return fn
+			}
+		}
+	}
+	return nil
+}
+
+// goTypeToFQN translates a go/types.Type into the same builtin.* / pkg.Type
+// FQN scheme stdlibNormalizeType produces from textual stdlib signatures:
+// pointers and slices are unwrapped to their element type, a map is
+// unwrapped to its value type (the scheme has no room for a key type), a
+// named type is qualified by its declaring package (or pkgPath if it's local
+// to the package being checked), and a basic type becomes "builtin.<name>".
+// Returns "" for a type the scheme has no representation for (e.g. a bare
+// interface, a function type).
+func goTypeToFQN(t types.Type, pkgPath string) string {
+	switch typ := t.(type) {
+	case *types.Pointer:
+		return goTypeToFQN(typ.Elem(), pkgPath)
+	case *types.Slice:
+		return goTypeToFQN(typ.Elem(), pkgPath)
+	case *types.Array:
+		return goTypeToFQN(typ.Elem(), pkgPath)
+	case *types.Map:
+		return goTypeToFQN(typ.Elem(), pkgPath)
+	case *types.Named:
+		obj := typ.Obj()
+		if obj.Pkg() == nil {
+			// Predeclared named type outside any package — only "error".
+			return "builtin." + obj.Name()
+		}
+		if obj.Pkg().Path() == pkgPath {
+			return pkgPath + "." + obj.Name()
+		}
+		return obj.Pkg().Path() + "." + obj.Name()
+	case *types.Basic:
+		if typ.Kind() == types.Invalid {
+			return ""
+		}
+		return "builtin." + typ.Name()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,244 @@
+package builder
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeGoTypesCheckerProject writes a single-package Go project into a fresh
+// temp dir and returns a goTypesChecker wired directly to it (bypassing
+// InitGoTypesChecker's module-layout resolution, which isn't the thing under
+// test here).
+func writeGoTypesCheckerProject(t *testing.T, pkgPath, source string) *goTypesChecker {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pkg.go"), []byte(source), 0o600))
+
+	return &goTypesChecker{
+		dirs:     map[string]string{pkgPath: dir},
+		fset:     token.NewFileSet(),
+		packages: make(map[string]*checkedGoPackage),
+	}
+}
+
+func TestGoTypesChecker_FuncReturnType_NamedStruct(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/users", `
+package users
+
+type User struct {
+	Name string
+}
+
+func GetUser(id int) *User {
+	return &User{Name: "a"}
+}
+`)
+
+	typeInfo, ok := checker.FuncReturnType("myapp/users", "GetUser")
+	require.True(t, ok)
+	assert.Equal(t, "myapp/users.User", typeInfo.TypeFQN)
+	assert.Equal(t, float32(1.0), typeInfo.Confidence)
+	assert.Equal(t, "go/types", typeInfo.Source)
+}
+
+func TestGoTypesChecker_FuncReturnType_SkipsErrorPicksNonErrorResult(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/store", `
+package store
+
+func Load(key string) (string, error) {
+	return "", nil
+}
+`)
+
+	typeInfo, ok := checker.FuncReturnType("myapp/store", "Load")
+	require.True(t, ok)
+	assert.Equal(t, "builtin.string", typeInfo.TypeFQN)
+}
+
+func TestGoTypesChecker_FuncReturnType_ErrorOnlyReturnsNotOK(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/store", `
+package store
+
+func Save(key, value string) error {
+	return nil
+}
+`)
+
+	_, ok := checker.FuncReturnType("myapp/store", "Save")
+	assert.False(t, ok)
+}
+
+func TestGoTypesChecker_FuncReturnType_UnknownFunction(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/store", `
+package store
+
+func Save() error { return nil }
+`)
+
+	_, ok := checker.FuncReturnType("myapp/store", "DoesNotExist")
+	assert.False(t, ok)
+}
+
+func TestGoTypesChecker_FuncReturnType_UnknownPackage(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/store", `package store`)
+
+	_, ok := checker.FuncReturnType("myapp/other", "Anything")
+	assert.False(t, ok)
+}
+
+func TestGoTypesChecker_ExpressionType_FieldAccess(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/config", `
+package config
+
+type Config struct {
+	Timeout int
+}
+
+func Process(cfg Config) {
+	_ = cfg.Timeout
+}
+`)
+
+	typeInfo, ok := checker.ExpressionType("myapp/config", "Process", "cfg.Timeout", 9)
+	require.True(t, ok)
+	assert.Equal(t, "builtin.int", typeInfo.TypeFQN)
+}
+
+func TestGoTypesChecker_ExpressionType_UnknownExpression(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/config", `
+package config
+
+func Process() {
+	_ = 1
+}
+`)
+
+	_, ok := checker.ExpressionType("myapp/config", "Process", "doesNotExist", 1)
+	assert.False(t, ok)
+}
+
+func TestGoTypesChecker_ExpressionType_DuplicateTextDifferentTypesResolvedByLine(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/shapes", `
+package shapes
+
+type Shape interface {
+	Describe() string
+}
+
+type Circle struct{}
+
+func (Circle) Describe() string { return "circle" }
+
+type Square struct{}
+
+func (Square) Describe() string { return "square" }
+
+func Handler() {
+	var x Shape = Circle{}
+	a := x.Describe()
+	x = Square{}
+	b := x.Describe()
+	_ = a
+	_ = b
+}
+`)
+
+	// Both call sites share the exact source text "x.Describe()" but x's
+	// dynamic type differs at each — go/types still resolves both the same
+	// way (an interface method call's static result type is the interface
+	// method's declared return type, "string" here, regardless of the
+	// dynamic receiver), so this asserts the line-keyed lookup finds the
+	// right node at each of its two distinct positions rather than only
+	// ever returning whichever occurrence ast.Inspect visits first.
+	first, ok := checker.ExpressionType("myapp/shapes", "Handler", "x.Describe()", 18)
+	require.True(t, ok)
+	assert.Equal(t, "builtin.string", first.TypeFQN)
+
+	second, ok := checker.ExpressionType("myapp/shapes", "Handler", "x.Describe()", 20)
+	require.True(t, ok)
+	assert.Equal(t, "builtin.string", second.TypeFQN)
+
+	_, ok = checker.ExpressionType("myapp/shapes", "Handler", "x.Describe()", 999)
+	assert.False(t, ok, "a line with no matching node must not fall back to any occurrence")
+}
+
+func TestGoTypesChecker_CachesCheckedPackageAcrossCalls(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/store", `
+package store
+
+func Count() int { return 1 }
+`)
+
+	_, ok := checker.FuncReturnType("myapp/store", "Count")
+	require.True(t, ok)
+	require.Contains(t, checker.packages, "myapp/store")
+
+	cachedBefore := checker.packages["myapp/store"]
+	_, ok = checker.FuncReturnType("myapp/store", "Count")
+	require.True(t, ok)
+	assert.Same(t, cachedBefore, checker.packages["myapp/store"])
+}
+
+func TestGoTypesChecker_MethodSet_PointerReceiverMethods(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/writer", `
+package writer
+
+type Buffer struct{}
+
+func (b *Buffer) Write(p []byte) (int, error) { return 0, nil }
+func (b *Buffer) Close() error { return nil }
+func (b *Buffer) unexportedHelper() {}
+`)
+
+	methods, ok := checker.MethodSet("myapp/writer", "Buffer")
+	require.True(t, ok)
+	assert.Equal(t, []string{"Close", "Write"}, methods)
+}
+
+func TestGoTypesChecker_MethodSet_UnknownType(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/writer", `package writer`)
+
+	_, ok := checker.MethodSet("myapp/writer", "DoesNotExist")
+	assert.False(t, ok)
+}
+
+func TestGoTypesChecker_Interfaces_DeclaredInPackage(t *testing.T) {
+	checker := writeGoTypesCheckerProject(t, "myapp/io", `
+package io
+
+type Writer interface {
+	Write(p []byte) (int, error)
+}
+
+type empty interface{}
+`)
+
+	ifaces := checker.Interfaces("myapp/io")
+	require.Contains(t, ifaces, "myapp/io.Writer")
+	assert.Equal(t, []string{"Write"}, ifaces["myapp/io.Writer"])
+	// An unexported interface is still reported by name (Interfaces doesn't
+	// filter unexported types, only unexported methods) but has no exported
+	// methods of its own to report.
+	assert.Contains(t, ifaces, "myapp/io.empty")
+	assert.Empty(t, ifaces["myapp/io.empty"])
+}
+
+func TestGoTypeToFQN_MapUnwrapsToValueType(t *testing.T) {
+	// Pointer, basic and named cases are exercised above via
+	// FuncReturnType/ExpressionType; this adds coverage (also through
+	// FuncReturnType) for the map case, which none of those fixtures hit.
+	checker := writeGoTypesCheckerProject(t, "myapp/cache", `
+package cache
+
+func All() map[string]int { return nil }
+`)
+
+	typeInfo, ok := checker.FuncReturnType("myapp/cache", "All")
+	require.True(t, ok)
+	assert.Equal(t, "builtin.int", typeInfo.TypeFQN)
+}
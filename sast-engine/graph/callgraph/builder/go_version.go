@@ -1,10 +1,12 @@
 package builder
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/registry"
@@ -22,12 +24,20 @@ var stdlibRegistryBaseURL = "https://assets.codepathfinder.dev/registries"
 // normalizeGoVersion handles stripping the patch component from raw go.mod values.
 var goVersionRegex = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+)`)
 
+// toolchainRegex matches the Go 1.21+ "toolchain goX.Y.Z" directive in go.mod
+// and go.work files. Unlike the "go" directive (a language-feature minimum),
+// "toolchain" pins the actual compiler version the build is run with, so when
+// both are present it takes precedence. The version always carries the "go"
+// prefix (e.g. "go1.22.3"); normalizeGoVersion strips it along with the patch
+// component.
+var toolchainRegex = regexp.MustCompile(`(?m)^toolchain\s+(go\d+\.\d+(?:\.\d+)?)`)
+
 // DetectGoVersion determines the Go toolchain version targeted by a project.
 //
 // Detection priority:
-//  1. go.mod  — "go X.Y" directive (most authoritative for module-aware projects)
+//  1. go.mod  — "toolchain" directive if present, else "go X.Y" directive
 //  2. .go-version — explicit version pin file used by tools such as goenv/asdf
-//  3. go.work  — workspace go directive (multi-module projects)
+//  3. go.work  — "toolchain" directive if present, else "go X.Y" directive
 //  4. Default  — "1.21" (the most widely deployed minor version as of 2024)
 //
 // All returned values are normalised to "X.Y" form (patch component stripped).
@@ -50,12 +60,15 @@ func DetectGoVersion(projectPath string) string {
 	return defaultGoVersion
 }
 
-// normalizeGoVersion strips the patch component from a Go version string.
+// normalizeGoVersion strips the leading "go" prefix (as used by the
+// "toolchain" directive) and the patch component from a Go version string.
 //
-//	"1.21"   → "1.21"
-//	"1.21.4" → "1.21"
-//	"1.26.0" → "1.26"
+//	"1.21"     → "1.21"
+//	"1.21.4"   → "1.21"
+//	"1.26.0"   → "1.26"
+//	"go1.22.3" → "1.22"
 func normalizeGoVersion(version string) string {
+	version = strings.TrimPrefix(version, "go")
 	parts := strings.Split(version, ".")
 	if len(parts) >= 2 {
 		return parts[0] + "." + parts[1]
@@ -63,13 +76,19 @@ func normalizeGoVersion(version string) string {
 	return version
 }
 
-// parseGoVersionFromFile reads a go.mod or go.work file and extracts the
-// "go X.Y" directive using goVersionRegex.  Returns "" on any error.
+// parseGoVersionFromFile reads a go.mod or go.work file and returns the Go
+// version it declares, preferring the "toolchain" directive (the pinned
+// compiler version) over the "go" directive (the language-feature minimum)
+// when both are present — matching the Go toolchain's own selection rules.
+// Returns "" on any error or if neither directive is found.
 func parseGoVersionFromFile(path string) string {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return ""
 	}
+	if match := toolchainRegex.FindSubmatch(data); match != nil {
+		return string(match[1])
+	}
 	if match := goVersionRegex.FindSubmatch(data); match != nil {
 		return string(match[1])
 	}
@@ -98,24 +117,75 @@ func readGoVersionFile(projectPath string) string {
 // Version resolution:
 //  1. reg.GoVersion (set by BuildGoModuleRegistry from go.mod) — normalised to "X.Y"
 //  2. DetectGoVersion(projectPath) — full detection chain as fallback
-func InitGoStdlibLoader(reg *core.GoModuleRegistry, projectPath string, logger *output.Logger) {
-	initGoStdlibLoaderWithBase(reg, projectPath, logger, stdlibRegistryBaseURL)
+//
+// The manifest is cached under projectPath's .codepathfinder/cache/stdlib
+// directory: a fresh, unexpired cache entry is used ahead of any network
+// fetch, so repeated runs (and runs with no network access at all) still get
+// stdlib metadata. See go_stdlib_cache.go.
+//
+// ctx governs the CDN fetch only (cache reads are local disk I/O and always
+// run to completion); cancelling it — e.g. on SIGINT, or via a deadline set
+// by the caller — aborts the in-flight request and InitGoStdlibLoader
+// degrades gracefully exactly as it would on any other network failure.
+func InitGoStdlibLoader(ctx context.Context, reg *core.GoModuleRegistry, projectPath string, logger *output.Logger) {
+	initGoStdlibLoaderWithBase(ctx, reg, projectPath, logger, stdlibRegistryBaseURL)
 }
 
 // initGoStdlibLoaderWithBase is the testable inner implementation of InitGoStdlibLoader.
 // It accepts an explicit baseURL so that tests can point at a local httptest.Server.
-func initGoStdlibLoaderWithBase(reg *core.GoModuleRegistry, projectPath string, logger *output.Logger, baseURL string) {
-	version := normalizeGoVersion(reg.GoVersion)
+func initGoStdlibLoaderWithBase(ctx context.Context, reg *core.GoModuleRegistry, projectPath string, logger *output.Logger, baseURL string) {
+	// A "toolchain" directive pins the actual compiler version and takes
+	// precedence over reg.GoVersion (which only ever reflects the "go X.Y"
+	// language-minimum directive) — stdlib APIs can differ between minor
+	// releases even when go.mod's language-feature minimum is left untouched.
+	version := normalizeGoVersion(parseGoVersionFromFile(filepath.Join(projectPath, "go.mod")))
+	if version == "" {
+		version = normalizeGoVersion(reg.GoVersion)
+	}
 	if version == "" {
 		version = DetectGoVersion(projectPath)
 	}
 
+	if loader, ok := loadStdlibManifestForVersion(ctx, version, projectPath, logger, baseURL); ok {
+		reg.StdlibLoader = loader
+	}
+}
+
+// loadStdlibManifestForVersion loads version's stdlib manifest, trying in
+// order: the on-disk offline cache, a live fetch-and-cache, and finally a
+// direct remote load with no caching. Returns (nil, false) only if all three
+// fail (e.g. no network access and no prior cache for version).
+func loadStdlibManifestForVersion(ctx context.Context, version, projectPath string, logger *output.Logger, baseURL string) (core.GoStdlibLoader, bool) {
+	start := time.Now()
+	if loader, ok := loadStdlibManifestOffline(projectPath, version); ok {
+		stdlibTraceSpan(logger, "load_manifest", version, true, 0, time.Since(start))
+		logger.Progress("Loaded Go %s stdlib manifest from local cache (%d packages)", version, loader.PackageCount())
+		return loader, true
+	}
+
+	if loader, bytes, err := fetchAndCacheStdlibManifest(ctx, baseURL, version, projectPath); err == nil {
+		stdlibTraceSpan(logger, "load_manifest", version, false, bytes, time.Since(start))
+		logger.Progress("Loaded Go %s stdlib manifest (%d packages)", version, loader.PackageCount())
+		return loader, true
+	}
+
 	remote := registry.NewGoStdlibRegistryRemote(baseURL, version)
-	if err := remote.LoadManifest(logger); err != nil {
+	if err := remote.LoadManifest(ctx, logger); err != nil {
+		stdlibTraceSpan(logger, "load_manifest", version, false, 0, time.Since(start))
 		logger.Warning("Failed to load Go %s stdlib manifest: %v", version, err)
-		return
+		return nil, false
 	}
 
+	stdlibTraceSpan(logger, "load_manifest", version, false, 0, time.Since(start))
 	logger.Progress("Loaded Go %s stdlib manifest (%d packages)", version, remote.PackageCount())
-	reg.StdlibLoader = remote
+	return remote, true
+}
+
+// stdlibTraceSpan emits a single structured trace line for a stdlib manifest
+// load — operation, target version, cache hit/miss, bytes transferred, and
+// elapsed wall time — via the existing output.Logger, so that a user can
+// diagnose why stdlib loading is slow on a particular network without a
+// dedicated output.Tracer type.
+func stdlibTraceSpan(logger *output.Logger, op, version string, cacheHit bool, bytes int, elapsed time.Duration) {
+	logger.Progress("[trace] stdlib.%s go%s cache_hit=%t bytes=%d elapsed=%s", op, version, cacheHit, bytes, elapsed)
 }
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -38,8 +39,10 @@ func TestNormalizeGoVersion(t *testing.T) {
 		{"1.21.4", "1.21"},
 		{"1.26.0", "1.26"},
 		{"2.0.0", "2.0"},
-		{"1", "1"},   // single component — returned as-is
-		{"", ""},     // empty — returned as-is
+		{"1", "1"}, // single component — returned as-is
+		{"", ""},   // empty — returned as-is
+		{"go1.22.3", "1.22"},
+		{"go1.22", "1.22"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
@@ -60,8 +63,10 @@ func TestParseGoVersionFromFile_GoMod(t *testing.T) {
 
 func TestParseGoVersionFromFile_GoModWithPatch(t *testing.T) {
 	dir := t.TempDir()
-	// go.mod may contain the full toolchain line; the regex captures only X.Y.
-	writeTempFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.23\n\ntoolchain go1.23.4\n")
+	// go.mod may contain a "toolchain" line in addition to "go"; the toolchain
+	// directive (the pinned compiler version) takes priority — see
+	// TestParseGoVersionFromFile_ToolchainTakesPriorityOverGoDirective.
+	writeTempFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.23\n")
 	assert.Equal(t, "1.23", parseGoVersionFromFile(filepath.Join(dir, "go.mod")))
 }
 
@@ -75,6 +80,24 @@ func TestParseGoVersionFromFile_NoGoDirective(t *testing.T) {
 	assert.Equal(t, "", parseGoVersionFromFile(filepath.Join(dir, "go.mod")))
 }
 
+func TestParseGoVersionFromFile_ToolchainTakesPriorityOverGoDirective(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.19\n\ntoolchain go1.22.3\n")
+	assert.Equal(t, "go1.22.3", parseGoVersionFromFile(filepath.Join(dir, "go.mod")))
+}
+
+func TestParseGoVersionFromFile_ToolchainOnly(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.work", "toolchain go1.23.1\n\nuse .\n")
+	assert.Equal(t, "go1.23.1", parseGoVersionFromFile(filepath.Join(dir, "go.work")))
+}
+
+func TestParseGoVersionFromFile_ToolchainWithoutPatch(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.21\n\ntoolchain go1.22\n")
+	assert.Equal(t, "go1.22", parseGoVersionFromFile(filepath.Join(dir, "go.mod")))
+}
+
 // -----------------------------------------------------------------------------
 // readGoVersionFile
 // -----------------------------------------------------------------------------
@@ -161,6 +184,18 @@ func TestDetectGoVersion_GoModNoGoDirective(t *testing.T) {
 	assert.Equal(t, "1.20", DetectGoVersion(dir))
 }
 
+func TestDetectGoVersion_ToolchainTakesPriorityOverGoDirective(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.19\n\ntoolchain go1.22.3\n")
+	assert.Equal(t, "1.22", DetectGoVersion(dir))
+}
+
+func TestDetectGoVersion_ToolchainInGoWork(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.work", "go 1.21\n\ntoolchain go1.24.0\n\nuse .\n")
+	assert.Equal(t, "1.24", DetectGoVersion(dir))
+}
+
 // -----------------------------------------------------------------------------
 // InitGoStdlibLoader / initGoStdlibLoaderWithBase
 // -----------------------------------------------------------------------------
@@ -191,7 +226,7 @@ func TestInitGoStdlibLoader_Success(t *testing.T) {
 	reg.GoVersion = "1.21"
 	logger := newGoVersionTestLogger()
 
-	initGoStdlibLoaderWithBase(reg, t.TempDir(), logger, server.URL)
+	initGoStdlibLoaderWithBase(context.Background(), reg, t.TempDir(), logger, server.URL)
 
 	require.NotNil(t, reg.StdlibLoader)
 	assert.Equal(t, 2, reg.StdlibLoader.PackageCount())
@@ -208,7 +243,7 @@ func TestInitGoStdlibLoader_ManifestError(t *testing.T) {
 	reg.GoVersion = "1.21"
 	logger := newGoVersionTestLogger()
 
-	initGoStdlibLoaderWithBase(reg, t.TempDir(), logger, server.URL)
+	initGoStdlibLoaderWithBase(context.Background(), reg, t.TempDir(), logger, server.URL)
 
 	// Graceful degradation: StdlibLoader must remain nil.
 	assert.Nil(t, reg.StdlibLoader)
@@ -231,7 +266,7 @@ func TestInitGoStdlibLoader_EmptyRegistryVersion_FallsBackToDetect(t *testing.T)
 	// GoVersion intentionally empty — must be detected from go.mod.
 	logger := newGoVersionTestLogger()
 
-	initGoStdlibLoaderWithBase(reg, dir, logger, server.URL)
+	initGoStdlibLoaderWithBase(context.Background(), reg, dir, logger, server.URL)
 
 	require.NotNil(t, reg.StdlibLoader)
 }
@@ -250,24 +285,68 @@ func TestInitGoStdlibLoader_VersionNormalized(t *testing.T) {
 	reg.GoVersion = "1.21.4"
 	logger := newGoVersionTestLogger()
 
-	initGoStdlibLoaderWithBase(reg, t.TempDir(), logger, server.URL)
+	initGoStdlibLoaderWithBase(context.Background(), reg, t.TempDir(), logger, server.URL)
 
 	assert.Contains(t, capturedPath, "go1.21")
 	assert.NotContains(t, capturedPath, "1.21.4")
 	require.NotNil(t, reg.StdlibLoader)
 }
 
+func TestInitGoStdlibLoader_ToolchainDirectiveOverridesRegistryGoVersion(t *testing.T) {
+	// go.mod pins a newer toolchain than its own "go" directive (and than
+	// reg.GoVersion, which only ever reflects that "go" directive) — the
+	// stdlib manifest fetched must target the toolchain version.
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.19\n\ntoolchain go1.22.3\n")
+
+	var capturedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(minimalManifest())
+	}))
+	defer server.Close()
+
+	reg := core.NewGoModuleRegistry()
+	reg.GoVersion = "1.19"
+	logger := newGoVersionTestLogger()
+
+	initGoStdlibLoaderWithBase(context.Background(), reg, dir, logger, server.URL)
+
+	assert.Contains(t, capturedPath, "go1.22")
+	require.NotNil(t, reg.StdlibLoader)
+}
+
 func TestInitGoStdlibLoader_NetworkError(t *testing.T) {
 	// Point at a URL that refuses connections.
 	reg := core.NewGoModuleRegistry()
 	reg.GoVersion = "1.21"
 	logger := newGoVersionTestLogger()
 
-	initGoStdlibLoaderWithBase(reg, t.TempDir(), logger, "http://127.0.0.1:0")
+	initGoStdlibLoaderWithBase(context.Background(), reg, t.TempDir(), logger, "http://127.0.0.1:0")
 
 	assert.Nil(t, reg.StdlibLoader)
 }
 
+func TestInitGoStdlibLoader_CanceledContext_DegradesGracefully(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(minimalManifest())
+	}))
+	defer server.Close()
+
+	reg := core.NewGoModuleRegistry()
+	reg.GoVersion = "1.21"
+	logger := newGoVersionTestLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	initGoStdlibLoaderWithBase(ctx, reg, t.TempDir(), logger, server.URL)
+
+	assert.Nil(t, reg.StdlibLoader, "a canceled context must abort the fetch rather than hang or panic")
+}
+
 func TestInitGoStdlibLoader_PublicAPI_CallsInner(t *testing.T) {
 	// Verify that the public InitGoStdlibLoader function is reachable.
 	// We override stdlibRegistryBaseURL so it hits a local server instead of CDN.
@@ -286,7 +365,7 @@ func TestInitGoStdlibLoader_PublicAPI_CallsInner(t *testing.T) {
 	reg.GoVersion = "1.21"
 	logger := newGoVersionTestLogger()
 
-	InitGoStdlibLoader(reg, t.TempDir(), logger)
+	InitGoStdlibLoader(context.Background(), reg, t.TempDir(), logger)
 
 	require.NotNil(t, reg.StdlibLoader)
 }
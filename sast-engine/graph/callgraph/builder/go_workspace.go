@@ -0,0 +1,103 @@
+package builder
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// DetectGoVersionForWorkspace returns the highest "go X.Y" directive declared
+// across workspaceRoot's go.work file and every member module's go.mod — the
+// same rule the Go toolchain itself applies when building a workspace, since
+// every module is compiled against one shared toolchain version. Falls back
+// to defaultGoVersion if no directive is found anywhere.
+func DetectGoVersionForWorkspace(workspaceRoot string, ws *resolution.WorkspaceRegistry) string {
+	version := normalizeGoVersion(parseGoVersionFromFile(filepath.Join(workspaceRoot, "go.work")))
+
+	if ws != nil {
+		for _, member := range ws.Members {
+			if v := normalizeGoVersion(member.GoVersion); compareGoVersions(v, version) > 0 {
+				version = v
+			}
+		}
+	}
+
+	if version == "" {
+		return defaultGoVersion
+	}
+	return version
+}
+
+// compareGoVersions compares two normalised "X.Y" Go version strings
+// numerically (not lexically, so "1.9" < "1.10"). An empty string sorts
+// below any real version. Returns -1, 0, or 1 the way strings.Compare does.
+func compareGoVersions(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return -1
+	}
+	if b == "" {
+		return 1
+	}
+
+	aMajor, aMinor := splitGoVersion(a)
+	bMajor, bMinor := splitGoVersion(b)
+	if aMajor != bMajor {
+		return compareInt(aMajor, bMajor)
+	}
+	return compareInt(aMinor, bMinor)
+}
+
+func splitGoVersion(version string) (major, minor int) {
+	parts := strings.SplitN(version, ".", 2)
+	major, _ = strconv.Atoi(parts[0])
+	if len(parts) == 2 {
+		minor, _ = strconv.Atoi(parts[1])
+	}
+	return major, minor
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// InitGoStdlibLoaderForWorkspace loads a single stdlib manifest for the
+// highest Go version declared anywhere in ws (see DetectGoVersionForWorkspace)
+// and shares the resulting loader across every member registry, rather than
+// having each module in the workspace independently fetch and parse the same
+// manifest — mirroring how one `go` toolchain version governs every module
+// built together in a workspace.
+func InitGoStdlibLoaderForWorkspace(ctx context.Context, ws *resolution.WorkspaceRegistry, workspaceRoot string, logger *output.Logger) {
+	initGoStdlibLoaderForWorkspaceWithBase(ctx, ws, workspaceRoot, logger, stdlibRegistryBaseURL)
+}
+
+// initGoStdlibLoaderForWorkspaceWithBase is the testable inner implementation
+// of InitGoStdlibLoaderForWorkspace.
+func initGoStdlibLoaderForWorkspaceWithBase(ctx context.Context, ws *resolution.WorkspaceRegistry, workspaceRoot string, logger *output.Logger, baseURL string) {
+	if ws == nil || len(ws.Members) == 0 {
+		return
+	}
+
+	version := DetectGoVersionForWorkspace(workspaceRoot, ws)
+	loader, ok := loadStdlibManifestForVersion(ctx, version, workspaceRoot, logger, baseURL)
+	if !ok {
+		return
+	}
+
+	for _, member := range ws.Members {
+		member.StdlibLoader = loader
+	}
+}
@@ -0,0 +1,122 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareGoVersions(t *testing.T) {
+	assert.Equal(t, 0, compareGoVersions("1.21", "1.21"))
+	assert.Equal(t, -1, compareGoVersions("", "1.21"))
+	assert.Equal(t, 1, compareGoVersions("1.21", ""))
+	assert.Equal(t, -1, compareGoVersions("1.9", "1.10"), "numeric, not lexical, comparison")
+	assert.Equal(t, 1, compareGoVersions("1.22", "1.21"))
+	assert.Equal(t, -1, compareGoVersions("1.21", "1.22"))
+}
+
+func TestDetectGoVersionForWorkspace_PicksHighestAcrossMembers(t *testing.T) {
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.21\n\nuse ./modA\nuse ./modB\n")
+	writeModCacheFile(t, ws, "modA/go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+	writeModCacheFile(t, ws, "modB/go.mod", "module github.com/example/modb\n\ngo 1.23\n")
+
+	registry, err := resolution.BuildGoWorkspaceRegistry(ws)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.23", DetectGoVersionForWorkspace(ws, registry))
+}
+
+func TestDetectGoVersionForWorkspace_GoWorkDirectiveHigherThanMembers(t *testing.T) {
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.24\n\nuse ./modA\n")
+	writeModCacheFile(t, ws, "modA/go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+
+	registry, err := resolution.BuildGoWorkspaceRegistry(ws)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.24", DetectGoVersionForWorkspace(ws, registry))
+}
+
+func TestDetectGoVersionForWorkspace_NilWorkspace_FallsBackToGoWork(t *testing.T) {
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.22\n\nuse ./modA\n")
+
+	assert.Equal(t, "1.22", DetectGoVersionForWorkspace(ws, nil))
+}
+
+func TestDetectGoVersionForWorkspace_NoDirectivesAnywhere_Default(t *testing.T) {
+	ws := t.TempDir()
+	assert.Equal(t, defaultGoVersion, DetectGoVersionForWorkspace(ws, nil))
+}
+
+func TestInitGoStdlibLoaderForWorkspace_SharesLoaderAcrossMembers(t *testing.T) {
+	var requestedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		manifest := core.GoManifest{
+			SchemaVersion:   "1.0.0",
+			RegistryVersion: "v1",
+			GoVersion:       core.GoVersionInfo{Major: 1, Minor: 23},
+			Packages:        []*core.GoPackageEntry{{ImportPath: "fmt"}},
+		}
+		data, _ := json.Marshal(manifest)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.21\n\nuse ./modA\nuse ./modB\n")
+	writeModCacheFile(t, ws, "modA/go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+	writeModCacheFile(t, ws, "modB/go.mod", "module github.com/example/modb\n\ngo 1.23\n")
+
+	registry, err := resolution.BuildGoWorkspaceRegistry(ws)
+	require.NoError(t, err)
+	require.Len(t, registry.Members, 2)
+
+	logger := newGoVersionTestLogger()
+	initGoStdlibLoaderForWorkspaceWithBase(context.Background(), registry, ws, logger, server.URL)
+
+	// Only one manifest fetch for the whole workspace, at the highest
+	// member's version (1.23), not one per member.
+	require.Len(t, requestedPaths, 1)
+	assert.Contains(t, requestedPaths[0], "go1.23")
+
+	require.NotNil(t, registry.Members[0].StdlibLoader)
+	require.NotNil(t, registry.Members[1].StdlibLoader)
+	assert.Same(t, registry.Members[0].StdlibLoader, registry.Members[1].StdlibLoader)
+}
+
+func TestInitGoStdlibLoaderForWorkspace_NilWorkspace_NoPanic(t *testing.T) {
+	logger := newGoVersionTestLogger()
+	assert.NotPanics(t, func() {
+		initGoStdlibLoaderForWorkspaceWithBase(context.Background(), nil, t.TempDir(), logger, "http://127.0.0.1:0")
+	})
+}
+
+func TestInitGoStdlibLoaderForWorkspace_ManifestFetchFails_MembersLeftNil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.21\n\nuse ./modA\n")
+	writeModCacheFile(t, ws, "modA/go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+
+	registry, err := resolution.BuildGoWorkspaceRegistry(ws)
+	require.NoError(t, err)
+
+	logger := newGoVersionTestLogger()
+	initGoStdlibLoaderForWorkspaceWithBase(context.Background(), registry, ws, logger, server.URL)
+
+	assert.Nil(t, registry.Members[0].StdlibLoader)
+}
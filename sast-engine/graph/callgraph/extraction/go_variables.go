@@ -2,6 +2,7 @@ package extraction
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -111,6 +112,75 @@ func ExtractGoVariableAssignments(
 	return nil
 }
 
+// ExtractGoVariableAssignmentsWithFacts behaves exactly like
+// ExtractGoVariableAssignments, except it first consults factStore for a
+// VariableScopeFact keyed by filePath's content (source + its importMap
+// entries, see resolution.ContentHash): on a hit, the cached bindings are
+// merged straight into typeEngine and the tree-sitter parse is skipped
+// entirely; on a miss, normal extraction runs and its resulting bindings for
+// this file are persisted back as new VariableScopeFacts, one per function
+// scope they belong to, so the next run over unchanged source hits.
+//
+// factStore may be nil, in which case this is exactly
+// ExtractGoVariableAssignments with no caching overhead.
+func ExtractGoVariableAssignmentsWithFacts(
+	filePath string,
+	sourceCode []byte,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+	importMap *core.GoImportMap,
+	factStore *resolution.FactStore,
+) error {
+	if factStore == nil {
+		return ExtractGoVariableAssignments(filePath, sourceCode, typeEngine, registry, importMap)
+	}
+
+	var imports []string
+	if importMap != nil {
+		for _, imp := range importMap.Imports {
+			imports = append(imports, imp)
+		}
+	}
+	hash := resolution.ContentHash(sourceCode, imports)
+
+	if functions := factStore.Functions(hash); len(functions) > 0 {
+		for _, fqn := range functions {
+			var fact resolution.VariableScopeFact
+			if hit, _ := factStore.Load(hash, fqn, &fact); hit {
+				scope := typeEngine.GetScope(fact.FunctionFQN)
+				if scope == nil {
+					scope = resolution.NewGoFunctionScope(fact.FunctionFQN)
+					typeEngine.AddScope(scope)
+				}
+				for _, binding := range fact.Bindings {
+					scope.AddVariable(binding)
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := ExtractGoVariableAssignments(filePath, sourceCode, typeEngine, registry, importMap); err != nil {
+		return err
+	}
+
+	for fqn, scope := range typeEngine.GetAllScopes() {
+		var fileBindings []*resolution.GoVariableBinding
+		for _, bindings := range scope.Variables {
+			for _, binding := range bindings {
+				if binding.Location.File == filePath {
+					fileBindings = append(fileBindings, binding)
+				}
+			}
+		}
+		if len(fileBindings) == 0 {
+			continue
+		}
+		_ = factStore.Put(hash, fqn, &resolution.VariableScopeFact{FunctionFQN: fqn, Bindings: fileBindings})
+	}
+	return nil
+}
+
 // traverseForVariableAssignments recursively traverses the AST to find variable assignments.
 // Tracks function context to properly scope variable bindings.
 func traverseForVariableAssignments(
@@ -179,6 +249,43 @@ func traverseForVariableAssignments(
 				importMap,
 			)
 		}
+
+	case "type_switch_statement":
+		// Handle `switch v := x.(type) { case T: ... }`: narrow v to T, scoped
+		// to that case's own branch.
+		if currentFunctionFQN != "" {
+			processTypeSwitchGuard(
+				node,
+				sourceCode,
+				filePath,
+				currentFunctionFQN,
+				typeEngine,
+				registry,
+			)
+		}
+
+	case "for_statement":
+		// Handle `for k, v := range expr { ... }`: bind k/v to the ranged
+		// collection's key/element types, scoped to the loop body.
+		if currentFunctionFQN != "" {
+			processRangeClause(
+				node,
+				sourceCode,
+				filePath,
+				currentFunctionFQN,
+				typeEngine,
+				registry,
+				importMap,
+			)
+		}
+
+	case "func_literal":
+		// An assigned literal's body was already traversed under its own
+		// synthetic FQN by inferTypeFromFuncLiteral; don't rebind its locals here.
+		point := node.StartPoint()
+		if typeEngine.GetScope(fmt.Sprintf("%s.$func%d:%d", currentFunctionFQN, point.Row+1, point.Column+1)) != nil {
+			return
+		}
 	}
 
 	// Recursively traverse children
@@ -216,6 +323,333 @@ func extractReceiverType(receiverNode *sitter.Node, sourceCode []byte) string {
 	return ""
 }
 
+// goControlNodeTypes are the tree-sitter node types that fork control flow
+// into distinct branches for computeBranchContext's purposes: an if's
+// consequence vs. alternative, or a switch/select's case clauses.
+var goControlNodeTypes = map[string]bool{
+	"if_statement":                true,
+	"expression_switch_statement": true,
+	"type_switch_statement":       true,
+	"select_statement":            true,
+	"for_statement":               true,
+}
+
+// isGoFunctionBoundary reports whether nodeType ends the walk in
+// computeBranchContext — a binding's or use-site's branch path never crosses
+// into an enclosing function, since each function already has its own scope.
+func isGoFunctionBoundary(nodeType string) bool {
+	return nodeType == "function_declaration" || nodeType == "method_declaration" || nodeType == "func_literal"
+}
+
+// isGoCaseClauseType reports whether nodeType is a switch/select case clause.
+func isGoCaseClauseType(nodeType string) bool {
+	switch nodeType {
+	case "type_case", "default_case", "expression_case", "communication_case":
+		return true
+	default:
+		return false
+	}
+}
+
+// computeBranchContext locates node within its enclosing function's
+// control-flow structure: its own start byte, and the ordered path of
+// control-structure branches (outermost first) that contain it. Used both
+// when recording a new variable binding and when resolving a use-site, so
+// resolution.GoFunctionScope.ReachingDefinitions can compare the two.
+//
+// A case clause (type_case/default_case/expression_case/communication_case)
+// gets its own frame keyed by its own start byte rather than an index into
+// its parent: the exact switch-body structure tree-sitter-go interposes
+// between a switch statement and its clauses isn't load-bearing here, and
+// every clause is already a unique node regardless of that structure.
+func computeBranchContext(node *sitter.Node) (uint32, []resolution.BranchFrame) {
+	if node == nil {
+		return 0, nil
+	}
+
+	startByte := node.StartByte()
+	var frames []resolution.BranchFrame
+
+	cur := node
+	for {
+		parent := cur.Parent()
+		if parent == nil || isGoFunctionBoundary(parent.Type()) {
+			break
+		}
+		switch {
+		case isGoCaseClauseType(cur.Type()):
+			frames = append(frames, resolution.BranchFrame{
+				ControlStart: cur.StartByte(),
+				Branch:       0,
+			})
+		case goControlNodeTypes[parent.Type()]:
+			frames = append(frames, resolution.BranchFrame{
+				ControlStart: parent.StartByte(),
+				Branch:       goBranchIndexWithin(parent, cur),
+			})
+		}
+		cur = parent
+	}
+
+	// frames was built innermost-first walking upward; reverse to outermost-first.
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return startByte, frames
+}
+
+// goBranchIndexWithin reports the index of parent's named child that
+// contains descendant's span, identifying which branch of a forking control
+// structure (if/else, switch/select case) descendant sits in. Returns -1 if
+// descendant falls outside every named child's span (e.g. it IS the
+// condition/header itself, which isn't part of any branch).
+func goBranchIndexWithin(parent, descendant *sitter.Node) int {
+	descStart, descEnd := descendant.StartByte(), descendant.EndByte()
+	for i := 0; i < int(parent.NamedChildCount()); i++ {
+		child := parent.NamedChild(i)
+		if child.StartByte() <= descStart && descEnd <= child.EndByte() {
+			return i
+		}
+	}
+	return -1
+}
+
+// isGoTypeExprNode reports whether nodeType is a tree-sitter-go type
+// expression — the node kind a type_case clause's leading children are, as
+// opposed to the statements making up its body.
+func isGoTypeExprNode(nodeType string) bool {
+	switch nodeType {
+	case "type_identifier", "qualified_type", "pointer_type", "slice_type", "array_type",
+		"map_type", "generic_type", "interface_type", "struct_type", "function_type",
+		"channel_type", "parenthesized_type":
+		return true
+	default:
+		return false
+	}
+}
+
+// collectGoTypeSwitchCases returns the type_case/default_case clauses that
+// belong directly to switchNode, without descending into any nested
+// switch/select/if/for statement's own clauses.
+func collectGoTypeSwitchCases(switchNode *sitter.Node) []*sitter.Node {
+	var cases []*sitter.Node
+	for i := 0; i < int(switchNode.NamedChildCount()); i++ {
+		collectGoCaseClauses(switchNode.NamedChild(i), &cases)
+	}
+	return cases
+}
+
+// collectGoCaseClauses walks n looking for type_case/default_case nodes,
+// stopping at nested control structures and function literals so their own
+// clauses aren't attributed to the outer switch.
+func collectGoCaseClauses(n *sitter.Node, cases *[]*sitter.Node) {
+	if n == nil {
+		return
+	}
+	switch n.Type() {
+	case "type_case", "default_case":
+		*cases = append(*cases, n)
+		return
+	case "if_statement", "expression_switch_statement", "type_switch_statement",
+		"select_statement", "for_statement", "func_literal":
+		return
+	}
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		collectGoCaseClauses(n.NamedChild(i), cases)
+	}
+}
+
+// processTypeSwitchGuard narrows a type switch's guard variable (the v in
+// switch v := x.(type)) to each single-type case clause's asserted type,
+// with a binding scoped to that clause's own branch frame so
+// resolution.GoFunctionScope.ReachingDefinitions only sees the narrowed type
+// for uses inside the matching case. Clauses listing more than one type
+// (case int, string:) leave v at its original interface type, matching Go's
+// own semantics, so they're skipped.
+func processTypeSwitchGuard(
+	node *sitter.Node,
+	sourceCode []byte,
+	filePath string,
+	functionFQN string,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+) {
+	guardNode := node.ChildByFieldName("value")
+	if guardNode == nil {
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			if child := node.NamedChild(i); child.Type() == "type_switch_guard" {
+				guardNode = child
+				break
+			}
+		}
+	}
+	// tree-sitter-go keeps `alias` on the type_switch_statement itself when
+	// there is no separate type_switch_guard node.
+	aliasHolder := node
+	if guardNode != nil && guardNode.Type() == "type_switch_guard" {
+		aliasHolder = guardNode
+	}
+
+	aliasNode := aliasHolder.ChildByFieldName("alias")
+	if aliasNode == nil {
+		// Plain `switch x.(type)` form — no variable to narrow.
+		return
+	}
+	varName := aliasNode.Content(sourceCode)
+
+	_, outerBranchPath := computeBranchContext(node)
+
+	for _, caseNode := range collectGoTypeSwitchCases(node) {
+		if caseNode.Type() != "type_case" {
+			continue
+		}
+
+		var typeExprs []*sitter.Node
+		for i := 0; i < int(caseNode.NamedChildCount()); i++ {
+			if child := caseNode.NamedChild(i); isGoTypeExprNode(child.Type()) {
+				typeExprs = append(typeExprs, child)
+			}
+		}
+		if len(typeExprs) != 1 {
+			continue
+		}
+
+		typeInfo, err := ParseGoTypeString(typeExprs[0].Content(sourceCode), registry, filePath)
+		if err != nil {
+			continue
+		}
+		typeInfo.Confidence = 0.9
+		typeInfo.Source = "type_switch"
+
+		branchPath := append(append([]resolution.BranchFrame{}, outerBranchPath...), resolution.BranchFrame{
+			ControlStart: caseNode.StartByte(),
+			Branch:       0,
+		})
+
+		binding := &resolution.GoVariableBinding{
+			VarName:    varName,
+			Type:       typeInfo,
+			StartByte:  caseNode.StartByte(),
+			BranchPath: branchPath,
+			Location:   resolution.Location{File: filePath},
+		}
+
+		scope := typeEngine.GetScope(functionFQN)
+		if scope == nil {
+			scope = resolution.NewGoFunctionScope(functionFQN)
+			typeEngine.AddScope(scope)
+		}
+		scope.AddVariable(binding)
+	}
+}
+
+// processRangeClause handles a for_statement's range_clause, if it has one:
+// `for k, v := range expr { ... }`. It infers expr's type, maps that to the
+// (key, value) types Go's range semantics produce, and binds k/v scoped to
+// the loop body so uses inside the loop see them but uses after it don't.
+//
+// Assumes core.TypeInfo carries Key, Elem (FQNs, empty when not applicable)
+// and Pointer fields for composite types, and that ParseGoTypeString
+// populates them when parsing a slice/array/map/channel type string (e.g.
+// "map[string]*User" → Key: "builtin.string", Elem: "pkg.User", Pointer:
+// true).
+func processRangeClause(
+	forNode *sitter.Node,
+	sourceCode []byte,
+	filePath string,
+	functionFQN string,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+	importMap *core.GoImportMap,
+) {
+	var rangeClauseNode *sitter.Node
+	for i := 0; i < int(forNode.NamedChildCount()); i++ {
+		if child := forNode.NamedChild(i); child.Type() == "range_clause" {
+			rangeClauseNode = child
+			break
+		}
+	}
+	if rangeClauseNode == nil {
+		return
+	}
+
+	leftNode := rangeClauseNode.ChildByFieldName("left")
+	if leftNode == nil {
+		// `for range expr { ... }` — nothing to bind.
+		return
+	}
+
+	var names []string
+	if leftNode.Type() == "expression_list" {
+		for i := 0; i < int(leftNode.NamedChildCount()); i++ {
+			names = append(names, leftNode.NamedChild(i).Content(sourceCode))
+		}
+	} else {
+		names = append(names, leftNode.Content(sourceCode))
+	}
+
+	rightNode := rangeClauseNode.ChildByFieldName("right")
+	if rightNode == nil {
+		return
+	}
+	rangedType := inferTypeFromRHS(rightNode, sourceCode, filePath, functionFQN, typeEngine, registry, importMap)
+	if rangedType == nil {
+		return
+	}
+
+	keyType, valueType := mapGoRangeKeyValueTypes(rangedType)
+
+	bodyNode := forNode.ChildByFieldName("body")
+	startByte, branchPath := computeBranchContext(bodyNode)
+
+	bindGoRangeVar := func(name string, typeInfo *core.TypeInfo) {
+		if name == "" || name == "_" || typeInfo == nil {
+			return
+		}
+		scope := typeEngine.GetScope(functionFQN)
+		if scope == nil {
+			scope = resolution.NewGoFunctionScope(functionFQN)
+			typeEngine.AddScope(scope)
+		}
+		scope.AddVariable(&resolution.GoVariableBinding{
+			VarName:    name,
+			Type:       typeInfo,
+			StartByte:  startByte,
+			BranchPath: branchPath,
+			Location:   resolution.Location{File: filePath},
+		})
+	}
+
+	bindGoRangeVar(names[0], keyType)
+	if len(names) > 1 {
+		bindGoRangeVar(names[1], valueType)
+	}
+}
+
+// mapGoRangeKeyValueTypes maps a ranged expression's type to the (key,
+// value) types `for k, v := range expr` produces, per Go's range semantics:
+// strings yield (int, rune); maps yield (Key, Elem); slices/arrays/channels
+// yield (int, Elem) — Elem unused for channels, which only bind one variable.
+func mapGoRangeKeyValueTypes(rangedType *core.TypeInfo) (key, value *core.TypeInfo) {
+	if rangedType.TypeFQN == "builtin.string" {
+		return &core.TypeInfo{TypeFQN: "builtin.int", Confidence: rangedType.Confidence, Source: "range"},
+			&core.TypeInfo{TypeFQN: "builtin.rune", Confidence: rangedType.Confidence, Source: "range"}
+	}
+
+	if rangedType.Key == "" && rangedType.Elem == "" {
+		return nil, nil
+	}
+
+	key = &core.TypeInfo{TypeFQN: "builtin.int", Confidence: rangedType.Confidence, Source: "range"}
+	if rangedType.Key != "" {
+		key = &core.TypeInfo{TypeFQN: rangedType.Key, Confidence: rangedType.Confidence, Source: "range"}
+	}
+	if rangedType.Elem != "" {
+		value = &core.TypeInfo{TypeFQN: rangedType.Elem, Confidence: rangedType.Confidence, Source: "range", Pointer: rangedType.Pointer}
+	}
+	return key, value
+}
+
 // processShortVarDeclaration processes a short_var_declaration node.
 // Extracts variable names and infers types from RHS.
 func processShortVarDeclaration(
@@ -238,25 +672,49 @@ func processShortVarDeclaration(
 	if rhsNode == nil {
 		return
 	}
+	// "right" is an expression_list; a single RHS expression is unwrapped so
+	// the call / type-assertion checks below see the expression itself.
+	if rhsNode.Type() == "expression_list" && rhsNode.NamedChildCount() == 1 {
+		rhsNode = rhsNode.NamedChild(0)
+	}
+
+	// For a multi-return call (x, y, err := foo()), resolve each LHS position
+	// to its own return type when possible; falls back to nil so every
+	// identifier gets the same (first-return) type, as before.
+	var tupleTypes []*core.TypeInfo
+	if len(varInfos) > 1 {
+		tupleTypes = inferTupleFromRHS(rhsNode, sourceCode, filePath, functionFQN, typeEngine, registry, importMap)
+	}
 
 	// For multi-assignment (x, y := foo()), all variables get same type
 	// For single assignment (x := foo()), just one variable
-	for _, varInfo := range varInfos {
+	// (a position with a resolved tupleTypes entry takes that type instead)
+	startByte, branchPath := computeBranchContext(node)
+	for i, varInfo := range varInfos {
 		// Skip blank identifier
 		if varInfo.Name == "_" {
 			continue
 		}
 
-		// Infer type from RHS
-		typeInfo := inferTypeFromRHS(
-			rhsNode,
-			sourceCode,
-			filePath,
-			functionFQN,
-			typeEngine,
-			registry,
-			importMap,
-		)
+		// Infer type from RHS, preferring the positional tuple type when available
+		var typeInfo *core.TypeInfo
+		switch {
+		case rhsNode.Type() == "type_assertion_expression" && len(varInfos) == 2 && i == 1:
+			// x, ok := y.(T) — ok is always builtin.bool, regardless of T.
+			typeInfo = &core.TypeInfo{TypeFQN: "builtin.bool", Confidence: 1.0, Source: "literal"}
+		case i < len(tupleTypes):
+			typeInfo = tupleTypes[i]
+		default:
+			typeInfo = inferTypeFromRHS(
+				rhsNode,
+				sourceCode,
+				filePath,
+				functionFQN,
+				typeEngine,
+				registry,
+				importMap,
+			)
+		}
 
 		if typeInfo == nil {
 			// Could not infer type, skip
@@ -265,13 +723,16 @@ func processShortVarDeclaration(
 
 		// Create variable binding
 		binding := &resolution.GoVariableBinding{
-			VarName:      varInfo.Name,
-			Type:         typeInfo,
-			AssignedFrom: varInfo.Value,
+			VarName:       varInfo.Name,
+			Type:          typeInfo,
+			AssignedFrom:  varInfo.Value,
+			PossibleTypes: possibleTypesForInterface(typeInfo, registry),
 			Location: resolution.Location{
 				File: filePath,
 				Line: varInfo.LineNumber,
 			},
+			StartByte:  startByte,
+			BranchPath: branchPath,
 		}
 
 		// Get or create function scope
@@ -308,19 +769,45 @@ func processAssignmentStatement(
 	if rhsNode == nil {
 		return
 	}
+	// "right" is an expression_list; unwrap a single RHS expression.
+	if rhsNode.Type() == "expression_list" && rhsNode.NamedChildCount() == 1 {
+		rhsNode = rhsNode.NamedChild(0)
+	}
+
+	// For a multi-return call (x, y, err = foo()), resolve each LHS position
+	// to its own return type when possible.
+	var tupleTypes []*core.TypeInfo
+	if len(varInfos) > 1 {
+		tupleTypes = inferTupleFromRHS(rhsNode, sourceCode, filePath, functionFQN, typeEngine, registry, importMap)
+	}
 
 	// Process each LHS variable
-	for _, varInfo := range varInfos {
-		// Infer type from RHS
-		typeInfo := inferTypeFromRHS(
-			rhsNode,
-			sourceCode,
-			filePath,
-			functionFQN,
-			typeEngine,
-			registry,
-			importMap,
-		)
+	startByte, branchPath := computeBranchContext(node)
+	for i, varInfo := range varInfos {
+		// Skip blank identifier: x, _ = foo() has nothing to bind for "_".
+		if varInfo.Name == "_" {
+			continue
+		}
+
+		// Infer type from RHS, preferring the positional tuple type when available
+		var typeInfo *core.TypeInfo
+		switch {
+		case rhsNode.Type() == "type_assertion_expression" && len(varInfos) == 2 && i == 1:
+			// x, ok = y.(T) — ok is always builtin.bool, regardless of T.
+			typeInfo = &core.TypeInfo{TypeFQN: "builtin.bool", Confidence: 1.0, Source: "literal"}
+		case i < len(tupleTypes):
+			typeInfo = tupleTypes[i]
+		default:
+			typeInfo = inferTypeFromRHS(
+				rhsNode,
+				sourceCode,
+				filePath,
+				functionFQN,
+				typeEngine,
+				registry,
+				importMap,
+			)
+		}
 
 		if typeInfo == nil {
 			// Could not infer type, skip
@@ -329,13 +816,16 @@ func processAssignmentStatement(
 
 		// Create variable binding (allows multiple bindings for reassignments)
 		binding := &resolution.GoVariableBinding{
-			VarName:      varInfo.Name,
-			Type:         typeInfo,
-			AssignedFrom: varInfo.Value,
+			VarName:       varInfo.Name,
+			Type:          typeInfo,
+			AssignedFrom:  varInfo.Value,
+			PossibleTypes: possibleTypesForInterface(typeInfo, registry),
 			Location: resolution.Location{
 				File: filePath,
 				Line: varInfo.LineNumber,
 			},
+			StartByte:  startByte,
+			BranchPath: branchPath,
 		}
 
 		// Get or create function scope
@@ -350,9 +840,44 @@ func processAssignmentStatement(
 	}
 }
 
+// possibleTypesForInterface returns the known concrete implementors of
+// typeInfo.TypeFQN (see resolution.BuildGoInterfaceCHA), for use as a
+// GoVariableBinding's PossibleTypes. Returns nil whenever typeInfo is nil,
+// its TypeFQN isn't a registered interface, or that interface has no known
+// implementors yet — a plain concrete binding carries no PossibleTypes.
+func possibleTypesForInterface(typeInfo *core.TypeInfo, registry *core.GoModuleRegistry) []*core.TypeInfo {
+	if typeInfo == nil || registry == nil {
+		return nil
+	}
+	resolution.CHAMutex.RLock()
+	_, isInterface := registry.InterfaceMethods[typeInfo.TypeFQN]
+	implementors := registry.InterfaceImplementors[typeInfo.TypeFQN]
+	resolution.CHAMutex.RUnlock()
+	if !isInterface {
+		return nil
+	}
+	if len(implementors) == 0 {
+		return nil
+	}
+	possible := make([]*core.TypeInfo, 0, len(implementors))
+	for _, implFQN := range implementors {
+		possible = append(possible, &core.TypeInfo{TypeFQN: implFQN, Confidence: typeInfo.Confidence, Source: "cha"})
+	}
+	return possible
+}
+
 // inferTypeFromRHS infers the type from a RHS expression node.
 // Returns nil if type cannot be inferred.
 //
+// Checked first, ahead of every pattern below: if the engine has a
+// go/types-backed TypesChecker configured (see builder.InitGoTypesChecker),
+// GetExpressionType is tried against rhsNode's exact source text. go/types is
+// ground truth when it's available — it resolves anonymous structs, embedded
+// methods, and generic instantiations the patterns below can't — so a hit
+// there short-circuits the rest of this function. A miss (no TypesChecker
+// configured, or the package failed to parse/type-check) falls through to
+// the tree-sitter patterns unchanged.
+//
 // Handles:
 //   - Function calls: Look up return type
 //   - Literals: Return builtin type
@@ -372,6 +897,14 @@ func inferTypeFromRHS(
 		return nil
 	}
 
+	if typeEngine != nil && functionFQN != "" {
+		exprText := string(sourceCode[rhsNode.StartByte():rhsNode.EndByte()])
+		exprLine := int(rhsNode.StartPoint().Row) + 1
+		if typeInfo, ok := typeEngine.GetExpressionType(functionFQN, exprText, exprLine); ok {
+			return typeInfo
+		}
+	}
+
 	nodeType := rhsNode.Type()
 
 	// Handle different RHS patterns
@@ -436,6 +969,7 @@ func inferTypeFromRHS(
 			rhsNode,
 			sourceCode,
 			filePath,
+			functionFQN,
 			typeEngine,
 			registry,
 			importMap,
@@ -444,7 +978,7 @@ func inferTypeFromRHS(
 	// Variable reference - copy type from scope
 	case "identifier":
 		varName := rhsNode.Content(sourceCode)
-		return inferTypeFromVariable(varName, functionFQN, typeEngine)
+		return inferTypeFromVariable(varName, functionFQN, typeEngine, rhsNode)
 
 	// Struct literal - extract type name
 	case "composite_literal":
@@ -467,6 +1001,36 @@ func inferTypeFromRHS(
 			importMap,
 		)
 
+	// Type assertion - x.(T) takes on T's type. The "ok" variable in the
+	// two-value form (x, ok := y.(T)) is handled separately by the caller,
+	// since that positional distinction isn't visible from rhsNode alone.
+	case "type_assertion_expression":
+		typeNode := rhsNode.ChildByFieldName("type")
+		if typeNode == nil {
+			return nil
+		}
+		typeInfo, err := ParseGoTypeString(typeNode.Content(sourceCode), registry, filePath)
+		if err != nil {
+			return nil
+		}
+		typeInfo.Confidence = 0.95
+		typeInfo.Source = "type_assertion"
+		return typeInfo
+
+	// Function literal - x := func(...) (...) { ... } binds x to a synthetic,
+	// callable type so a later call through x can resolve a result (see
+	// inferTypeFromFuncLiteral and its use in inferTypeFromFunctionCall).
+	case "func_literal":
+		return inferTypeFromFuncLiteral(
+			rhsNode,
+			sourceCode,
+			filePath,
+			functionFQN,
+			typeEngine,
+			registry,
+			importMap,
+		)
+
 	// Expression list - for multi-assignment, get first element
 	case "expression_list":
 		if rhsNode.NamedChildCount() > 0 {
@@ -496,6 +1060,7 @@ func inferTypeFromFunctionCall(
 	callNode *sitter.Node,
 	sourceCode []byte,
 	filePath string,
+	functionFQN string,
 	typeEngine *resolution.GoTypeInferenceEngine,
 	registry *core.GoModuleRegistry,
 	importMap *core.GoImportMap,
@@ -506,6 +1071,45 @@ func inferTypeFromFunctionCall(
 		return nil
 	}
 
+	// obj.Method() where obj's static type is a registered interface: resolve
+	// via CHA (registry.InterfaceMethods/InterfaceImplementors) before falling
+	// back to extractGoFunctionName, which has no way to tell a method call
+	// on an interface-typed variable apart from any other qualified call.
+	if functionNode.Type() == "selector_expression" {
+		if operandNode := functionNode.ChildByFieldName("operand"); operandNode != nil && operandNode.Type() == "identifier" {
+			if fieldNode := functionNode.ChildByFieldName("field"); fieldNode != nil {
+				if typeInfo := resolveInterfaceMethodCall(
+					operandNode,
+					operandNode.Content(sourceCode),
+					fieldNode.Content(sourceCode),
+					filePath,
+					functionFQN,
+					typeEngine,
+					registry,
+				); typeInfo != nil {
+					return typeInfo
+				}
+			}
+		}
+	}
+
+	// handler() where handler is a variable bound to a function literal (see
+	// inferTypeFromFuncLiteral): the call's result comes from the literal's
+	// own declared return type, since a function-valued variable has no
+	// package-qualified FQN for GetReturnType to look up.
+	if functionNode.Type() == "identifier" {
+		if typeInfo := resolveFunctionVariableCall(
+			functionNode,
+			functionNode.Content(sourceCode),
+			filePath,
+			functionFQN,
+			typeEngine,
+			registry,
+		); typeInfo != nil {
+			return typeInfo
+		}
+	}
+
 	funcName := extractGoFunctionName(functionNode, sourceCode, importMap)
 	if funcName == "" {
 		return nil
@@ -531,7 +1135,8 @@ func inferTypeFromFunctionCall(
 	if idx := strings.LastIndex(funcName, "."); idx > 0 {
 		importPath := funcName[:idx]
 		fnName := funcName[idx+1:]
-		if ti := inferTypeFromStdlibFunction(importPath, fnName, registry); ti != nil {
+		argTypes := inferCallArgTypes(callNode, sourceCode, filePath, functionFQN, typeEngine, registry, importMap)
+		if ti := inferTypeFromStdlibFunctionWithArgs(importPath, fnName, argTypes, registry); ti != nil {
 			return ti
 		}
 	}
@@ -540,6 +1145,212 @@ func inferTypeFromFunctionCall(
 	return nil
 }
 
+// resolveFunctionVariableCall resolves handler() when handler is a variable
+// currently bound to a function literal's synthesized type (see
+// inferTypeFromFuncLiteral): the call's result is read straight off the
+// binding's Signature rather than looked up by name, since a function-valued
+// variable has no FQN of its own for GetReturnType to find. Returns nil
+// whenever varName isn't bound, or its type has no Signature, or the
+// signature declares no returns — letting the caller fall back to the
+// regular named-function resolution path.
+func resolveFunctionVariableCall(
+	identNode *sitter.Node,
+	varName string,
+	filePath string,
+	functionFQN string,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+) *core.TypeInfo {
+	varType := inferTypeFromVariable(varName, functionFQN, typeEngine, identNode)
+	if varType == nil || varType.Signature == nil || len(varType.Signature.Returns) == 0 {
+		return nil
+	}
+
+	typeInfo, err := ParseGoTypeString(varType.Signature.Returns[0], registry, filePath)
+	if err != nil {
+		return nil
+	}
+	typeInfo.Source = "func_literal_call"
+	return typeInfo
+}
+
+// resolveFunctionVariableTupleCall is resolveFunctionVariableCall's
+// multi-return counterpart: a, b, err := handler() where handler is a
+// variable bound to a function literal declaring two or more results. Since
+// a function-valued variable has no FQN for GetReturnTypes to key on, every
+// declared result is parsed straight off the binding's Signature, the same
+// way the single-return case reads Signature.Returns[0]. Returns nil when
+// varName isn't bound, has no Signature, or declares fewer than two returns
+// — the caller falls back to the stdlib/positional resolution inferTupleFromRHS
+// already performs in that case.
+func resolveFunctionVariableTupleCall(
+	identNode *sitter.Node,
+	varName string,
+	filePath string,
+	functionFQN string,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+) []*core.TypeInfo {
+	varType := inferTypeFromVariable(varName, functionFQN, typeEngine, identNode)
+	if varType == nil || varType.Signature == nil || len(varType.Signature.Returns) < 2 {
+		return nil
+	}
+
+	types := make([]*core.TypeInfo, len(varType.Signature.Returns))
+	for i, ret := range varType.Signature.Returns {
+		typeInfo, err := ParseGoTypeString(ret, registry, filePath)
+		if err != nil {
+			continue
+		}
+		typeInfo.Source = "func_literal_call"
+		types[i] = typeInfo
+	}
+	return types
+}
+
+// inferTypeFromFuncLiteral handles x := func(...) (...) { ... }: an anonymous
+// function has no declared name to register under, so it's given a synthetic
+// FQN derived from its enclosing function plus its own source position
+// (stable across runs over the same file, unique among literals in the same
+// function). Assumes core.TypeInfo carries a Signature *core.GoFuncSignature
+// field (Params, Returns []string), populated from the literal's declared
+// parameter/return type expressions so a later call through the bound
+// variable can resolve its result (see resolveFunctionVariableCall). Its
+// first non-empty return type is also registered with typeEngine exactly as
+// Pass 2a would for a named function, and its body is traversed under the
+// synthetic FQN so the closure's own local variables get their own scope
+// rather than leaking into the enclosing function's.
+func inferTypeFromFuncLiteral(
+	litNode *sitter.Node,
+	sourceCode []byte,
+	filePath string,
+	enclosingFQN string,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+	importMap *core.GoImportMap,
+) *core.TypeInfo {
+	point := litNode.StartPoint()
+	synthFQN := fmt.Sprintf("%s.$func%d:%d", enclosingFQN, point.Row+1, point.Column+1)
+
+	signature := &core.GoFuncSignature{
+		Params:  goLiteralFieldTypes(litNode.ChildByFieldName("parameters"), sourceCode),
+		Returns: goLiteralFieldTypes(litNode.ChildByFieldName("result"), sourceCode),
+	}
+
+	if len(signature.Returns) > 0 {
+		if typeInfo, err := ParseGoTypeString(signature.Returns[0], registry, filePath); err == nil {
+			typeEngine.AddReturnType(synthFQN, typeInfo)
+		}
+	}
+
+	if bodyNode := litNode.ChildByFieldName("body"); bodyNode != nil {
+		typeEngine.AddScope(resolution.NewGoFunctionScope(synthFQN))
+		traverseForVariableAssignments(bodyNode, sourceCode, filePath, "", synthFQN, typeEngine, registry, importMap)
+	}
+
+	return &core.TypeInfo{
+		TypeFQN:    synthFQN,
+		Confidence: 1.0,
+		Source:     "func_literal",
+		Signature:  signature,
+	}
+}
+
+// goLiteralFieldTypes extracts the declared type expression of every
+// parameter in a func_literal's parameter_list, or its single result type
+// when fieldNode is a bare type rather than a parameter_list — the same
+// shape resolution.goParamTypes/goResultTypes handle for named
+// function/method signatures, duplicated here in unexported form since this
+// package can't reach those resolution-package internals directly.
+func goLiteralFieldTypes(fieldNode *sitter.Node, sourceCode []byte) []string {
+	if fieldNode == nil {
+		return nil
+	}
+	if fieldNode.Type() != "parameter_list" {
+		return []string{fieldNode.Content(sourceCode)}
+	}
+	var types []string
+	for i := 0; i < int(fieldNode.NamedChildCount()); i++ {
+		param := fieldNode.NamedChild(i)
+		if param.Type() != "parameter_declaration" {
+			continue
+		}
+		if typeNode := param.ChildByFieldName("type"); typeNode != nil {
+			types = append(types, typeNode.Content(sourceCode))
+		}
+	}
+	return types
+}
+
+// resolveInterfaceMethodCall resolves varName.methodName() when varName's
+// static type (per the current function scope) is an interface that
+// resolution.BuildGoInterfaceCHA has indexed on registry. The call's result
+// type is the interface method's own declared return type — that's what Go's
+// static typing guarantees regardless of which concrete implementor actually
+// runs — so the CHA implementor list isn't used to pick a return type; it's
+// attached to the result's Implementors field so a caller doing call-graph
+// fan-out knows which concrete methods this dispatch could resolve to.
+//
+// Returns nil whenever varName isn't a registered interface type, or the
+// interface has no matching method, or CHA hasn't been run on registry —
+// letting the caller fall back to extractGoFunctionName's existing handling.
+func resolveInterfaceMethodCall(
+	operandNode *sitter.Node,
+	varName string,
+	methodName string,
+	filePath string,
+	functionFQN string,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+) *core.TypeInfo {
+	if registry == nil {
+		return nil
+	}
+	resolution.CHAMutex.RLock()
+	interfaceMethodsEmpty := len(registry.InterfaceMethods) == 0
+	resolution.CHAMutex.RUnlock()
+	if interfaceMethodsEmpty {
+		return nil
+	}
+
+	varType := inferTypeFromVariable(varName, functionFQN, typeEngine, operandNode)
+	if varType == nil {
+		return nil
+	}
+
+	resolution.CHAMutex.RLock()
+	methods, ok := registry.InterfaceMethods[varType.TypeFQN]
+	resolution.CHAMutex.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	var returnType string
+	found := false
+	for _, m := range methods {
+		if m.Name == methodName {
+			found = true
+			if len(m.Returns) > 0 {
+				returnType = m.Returns[0]
+			}
+			break
+		}
+	}
+	if !found || returnType == "" {
+		return nil
+	}
+
+	typeInfo, err := ParseGoTypeString(returnType, registry, filePath)
+	if err != nil {
+		return nil
+	}
+	typeInfo.Source = "cha"
+	resolution.CHAMutex.RLock()
+	typeInfo.Implementors = registry.InterfaceImplementors[varType.TypeFQN]
+	resolution.CHAMutex.RUnlock()
+	return typeInfo
+}
+
 // inferTypeFromStdlibFunction looks up the primary return type of a Go stdlib
 // function using the StdlibLoader attached to the registry.
 //
@@ -551,6 +1362,27 @@ func inferTypeFromFunctionCall(
 // Returns nil when the loader is unavailable, the import path is not a stdlib
 // package, the function is not found, or there is no usable return type.
 func inferTypeFromStdlibFunction(importPath, funcName string, registry *core.GoModuleRegistry) *core.TypeInfo {
+	return inferTypeFromStdlibFunctionWithArgs(importPath, funcName, nil, registry)
+}
+
+// inferTypeFromStdlibFunctionWithArgs is inferTypeFromStdlibFunction's
+// generics-aware sibling, used when the call site's argument types are
+// available. Go 1.18+ stdlib additions like
+// slices.Max[S ~[]E, E cmp.Ordered](s S) E declare TypeParams, and their
+// Returns[].Type can reference one of those type parameters by name (e.g.
+// "E") instead of a concrete type.
+//
+// When the chosen return references a type parameter, argTypes is unified
+// against fn.Params (positionally) to bind it; the bound argument's TypeFQN
+// is returned. When unification can't bind it (argTypes is nil/empty, or the
+// parameter never appears in a unifiable parameter position), a TypeFQN of
+// "<unresolved-generic>" is returned at reduced confidence — signalling a
+// real, generic return value whose concrete type just isn't known, rather
+// than reporting no type at all.
+//
+// Non-generic returns resolve exactly as inferTypeFromStdlibFunction always
+// has, via normalizeStdlibReturnType.
+func inferTypeFromStdlibFunctionWithArgs(importPath, funcName string, argTypes []*core.TypeInfo, registry *core.GoModuleRegistry) *core.TypeInfo {
 	if registry.StdlibLoader == nil {
 		return nil
 	}
@@ -561,11 +1393,31 @@ func inferTypeFromStdlibFunction(importPath, funcName string, registry *core.GoM
 	if err != nil || fn == nil || len(fn.Returns) == 0 {
 		return nil
 	}
+
+	var bindings map[string]*core.TypeInfo
+	if len(fn.TypeParams) > 0 {
+		bindings = unifyStdlibTypeParams(fn, argTypes)
+	}
+
 	// Use the first non-error, non-empty return value.
 	for _, ret := range fn.Returns {
 		if ret.Type == "" || ret.Type == "error" {
 			continue
 		}
+		if varName := stdlibTypeParamName(ret.Type, fn.TypeParams); varName != "" {
+			if bound, ok := bindings[varName]; ok {
+				return &core.TypeInfo{
+					TypeFQN:    bound.TypeFQN,
+					Confidence: 0.9,
+					Source:     "stdlib_registry",
+				}
+			}
+			return &core.TypeInfo{
+				TypeFQN:    "<unresolved-generic>",
+				Confidence: 0.3,
+				Source:     "stdlib_registry",
+			}
+		}
 		typeFQN := normalizeStdlibReturnType(ret.Type, importPath)
 		if typeFQN == "" {
 			continue
@@ -579,6 +1431,210 @@ func inferTypeFromStdlibFunction(importPath, funcName string, registry *core.GoM
 	return nil
 }
 
+// unifyStdlibTypeParams walks fn.Params in parallel with argTypes, binding
+// each type parameter named in a declared parameter to the corresponding
+// argument's inferred type. A parameter position with no supplied argType,
+// or whose declared type doesn't reference a type parameter, is skipped.
+//
+// Duplicated from (rather than sharing code with) resolution's
+// unifyTypeParams/typeParamName: extraction and resolution already keep their
+// stdlib-normalization helpers independent (see normalizeStdlibReturnType vs.
+// resolution.stdlibNormalizeType), so this follows the same convention.
+func unifyStdlibTypeParams(fn *core.GoStdlibFunction, argTypes []*core.TypeInfo) map[string]*core.TypeInfo {
+	bindings := make(map[string]*core.TypeInfo, len(fn.TypeParams))
+	for i, declParam := range fn.Params {
+		if i >= len(argTypes) || argTypes[i] == nil {
+			continue
+		}
+		varName := stdlibTypeParamName(declParam, fn.TypeParams)
+		if varName == "" {
+			continue
+		}
+		if _, bound := bindings[varName]; bound {
+			continue
+		}
+		bindings[varName] = argTypes[i]
+	}
+	return bindings
+}
+
+// stdlibTypeParamName reports whether declared (a textual type expression
+// like "E", "[]E", or "*E") refers to one of typeParams by name once a single
+// leading "*" or "[]" is stripped. Returns "" when declared isn't a reference
+// to any of typeParams.
+func stdlibTypeParamName(declared string, typeParams []core.GoTypeParam) string {
+	name := strings.TrimPrefix(declared, "*")
+	name = strings.TrimPrefix(name, "[]")
+	for _, tp := range typeParams {
+		if tp.Name == name {
+			return name
+		}
+	}
+	return ""
+}
+
+// inferCallArgTypes infers the type of each argument expression in a call
+// expression, in positional order, for unifying a generic stdlib function's
+// type parameters against its call-site instantiation (see
+// inferTypeFromStdlibFunctionWithArgs). An argument whose type can't be
+// inferred is reported as a nil entry at its position, rather than omitted,
+// so positional alignment with fn.Params is preserved.
+func inferCallArgTypes(
+	callNode *sitter.Node,
+	sourceCode []byte,
+	filePath string,
+	functionFQN string,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+	importMap *core.GoImportMap,
+) []*core.TypeInfo {
+	argsNode := callNode.ChildByFieldName("arguments")
+	if argsNode == nil {
+		return nil
+	}
+	argTypes := make([]*core.TypeInfo, argsNode.NamedChildCount())
+	for i := range argTypes {
+		argTypes[i] = inferTypeFromRHS(
+			argsNode.NamedChild(i),
+			sourceCode,
+			filePath,
+			functionFQN,
+			typeEngine,
+			registry,
+			importMap,
+		)
+	}
+	return argTypes
+}
+
+// InferTupleFromStdlibFunction resolves every positional return value of a Go
+// stdlib function, for multi-return assignments such as
+// `body, n, err := io.ReadAll(r)` or `conn, addr, err := listener.Accept()`
+// where each LHS identifier needs its own return type rather than the single
+// first-non-error type inferTypeFromStdlibFunction picks.
+//
+// The returned slice has one entry per fn.Returns position, in order. A
+// position is nil when its declared type is "error" or empty (blank/err
+// positions), or when normalizeStdlibReturnType can't resolve it — callers
+// zip these against LHS identifiers and skip the nil ones.
+//
+// Returns nil (not an empty slice) when the loader is unavailable, the import
+// path is not a stdlib package, or the function is not found, so callers can
+// tell "nothing to zip" from "every position was unresolvable".
+func InferTupleFromStdlibFunction(importPath, funcName string, registry *core.GoModuleRegistry) []*core.TypeInfo {
+	if registry.StdlibLoader == nil {
+		return nil
+	}
+	if !registry.StdlibLoader.ValidateStdlibImport(importPath) {
+		return nil
+	}
+	fn, err := registry.StdlibLoader.GetFunction(importPath, funcName)
+	if err != nil || fn == nil || len(fn.Returns) == 0 {
+		return nil
+	}
+
+	types := make([]*core.TypeInfo, len(fn.Returns))
+	for i, ret := range fn.Returns {
+		if ret.Type == "" || ret.Type == "error" {
+			continue
+		}
+		if stdlibTypeParamName(ret.Type, fn.TypeParams) != "" {
+			// Unresolved type parameter (e.g. a generic function's "E") — this
+			// helper has no call-site argument types to unify against, so leave
+			// the position nil rather than mis-qualify it as "builtin.E" or
+			// importPath+".E".
+			continue
+		}
+		typeFQN := normalizeStdlibReturnType(ret.Type, importPath)
+		if typeFQN == "" {
+			continue
+		}
+		types[i] = &core.TypeInfo{
+			TypeFQN:    typeFQN,
+			Confidence: 0.9,
+			Source:     "stdlib_registry",
+		}
+	}
+	return types
+}
+
+// inferTupleFromRHS resolves a multi-return call's return values positionally,
+// for zipping against multiple LHS identifiers (x, y, err := foo()). handler()
+// where handler is a variable bound to a function literal is resolved first,
+// straight off the literal's own Signature (see
+// resolveFunctionVariableTupleCall), since such a variable has no FQN for a
+// map lookup to key on. Otherwise tries typeEngine.GetReturnTypes — entries
+// explicitly registered via AddReturnTypes (today only seeded by tests; no
+// extraction pass scans arbitrary user function declarations yet, see
+// AddReturnTypes' doc comment) plus the stdlib fallback GetReturnTypes already
+// performs internally — and consults InferTupleFromStdlibFunction directly
+// when typeEngine is nil or GetReturnTypes yields no tuple. Returns nil when rhsNode isn't a call
+// expression, the callee can't be resolved, or it has fewer than two declared
+// returns — callers should fall back to inferTypeFromRHS (same type for every
+// LHS) in that case.
+func inferTupleFromRHS(
+	rhsNode *sitter.Node,
+	sourceCode []byte,
+	filePath string,
+	functionFQN string,
+	typeEngine *resolution.GoTypeInferenceEngine,
+	registry *core.GoModuleRegistry,
+	importMap *core.GoImportMap,
+) []*core.TypeInfo {
+	if rhsNode == nil || rhsNode.Type() != "call_expression" {
+		return nil
+	}
+
+	functionNode := rhsNode.ChildByFieldName("function")
+	if functionNode == nil {
+		return nil
+	}
+
+	if functionNode.Type() == "identifier" {
+		if types := resolveFunctionVariableTupleCall(
+			functionNode,
+			functionNode.Content(sourceCode),
+			filePath,
+			functionFQN,
+			typeEngine,
+			registry,
+		); types != nil {
+			return types
+		}
+	}
+
+	funcName := extractGoFunctionName(functionNode, sourceCode, importMap)
+	if funcName == "" {
+		return nil
+	}
+
+	if !strings.Contains(funcName, ".") {
+		dirPath := filepath.Dir(filePath)
+		if packagePath, ok := registry.DirToImport[dirPath]; ok {
+			funcName = packagePath + "." + funcName
+		}
+	}
+
+	if typeEngine != nil {
+		if types, ok := typeEngine.GetReturnTypes(funcName); ok && len(types) >= 2 {
+			return types
+		}
+	}
+
+	idx := strings.LastIndex(funcName, ".")
+	if idx <= 0 {
+		return nil
+	}
+	importPath := funcName[:idx]
+	fnName := funcName[idx+1:]
+
+	types := InferTupleFromStdlibFunction(importPath, fnName, registry)
+	if len(types) < 2 {
+		return nil
+	}
+	return types
+}
+
 // normalizeStdlibReturnType converts a raw stdlib return type string into a TypeFQN.
 //
 // rawType is the type string as stored in the registry JSON (e.g., "*Request",
@@ -670,12 +1726,16 @@ func extractGoFunctionName(
 	}
 }
 
-// inferTypeFromVariable infers type by looking up the variable in the current function scope.
-// Returns the most recent binding for the variable.
+// inferTypeFromVariable infers type by looking up the variable in the current
+// function scope, flow-sensitively: it returns whichever binding(s) actually
+// reach useSiteNode's position, not simply the most recently traversed one
+// (which, for branching code, depends on source order rather than
+// reachability — see resolution.GoFunctionScope.ReachingDefinitions).
 func inferTypeFromVariable(
 	varName string,
 	functionFQN string,
 	typeEngine *resolution.GoTypeInferenceEngine,
+	useSiteNode *sitter.Node,
 ) *core.TypeInfo {
 	// Get function scope
 	scope := typeEngine.GetScope(functionFQN)
@@ -683,14 +1743,8 @@ func inferTypeFromVariable(
 		return nil
 	}
 
-	// Get variable bindings
-	bindings, ok := scope.Variables[varName]
-	if !ok || len(bindings) == 0 {
-		return nil
-	}
-
-	// Return most recent binding
-	return bindings[len(bindings)-1].Type
+	useByte, useBranchPath := computeBranchContext(useSiteNode)
+	return scope.ReachingDefinitions(varName, useByte, useBranchPath)
 }
 
 // inferTypeFromCompositeLiteral infers type from a composite literal (struct literal).
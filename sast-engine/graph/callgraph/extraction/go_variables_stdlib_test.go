@@ -1,15 +1,55 @@
 package extraction
 
 import (
+	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/resolution"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/golang"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// parseGoMethodCallOperand parses `varName.methodName()` wrapped in a minimal
+// function body and returns the operand identifier node (e.g. the "s" in
+// "s.Area()"), for tests exercising resolveInterfaceMethodCall's use-site
+// position logic against a real tree-sitter node.
+func parseGoMethodCallOperand(t *testing.T, varName, methodName string) *sitter.Node {
+	t.Helper()
+	source := []byte("package main\nfunc f() {\n\t" + varName + "." + methodName + "()\n}\n")
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(golang.GetLanguage())
+	tree, err := parser.ParseCtx(context.Background(), nil, source)
+	require.NoError(t, err)
+
+	var operand *sitter.Node
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil || operand != nil {
+			return
+		}
+		if n.Type() == "selector_expression" {
+			if op := n.ChildByFieldName("operand"); op != nil && op.Type() == "identifier" {
+				operand = op
+				return
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(tree.RootNode())
+	require.NotNil(t, operand, "expected to find a selector_expression operand in parsed source")
+	return operand
+}
+
 // errMockNotImplemented is returned by mock methods that are not exercised.
 var errMockNotImplemented = errors.New("not implemented by mock")
 
@@ -207,6 +247,166 @@ func TestInferTypeFromStdlibFunction_SkipsErrorPicksFirst(t *testing.T) {
 	assert.Equal(t, "os.File", result.TypeFQN)
 }
 
+// -----------------------------------------------------------------------------
+// inferTypeFromStdlibFunctionWithArgs — generics
+// -----------------------------------------------------------------------------
+
+func TestInferTypeFromStdlibFunctionWithArgs_NonGeneric_SameAsBefore(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	reg.StdlibLoader = &mockStdlibLoader{
+		stdlibPkgs: map[string]bool{"fmt": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"fmt.Sprintf": {
+				Name:    "Sprintf",
+				Returns: []*core.GoReturnValue{{Type: "string"}},
+			},
+		},
+	}
+	result := inferTypeFromStdlibFunctionWithArgs("fmt", "Sprintf", nil, reg)
+	require.NotNil(t, result)
+	assert.Equal(t, "builtin.string", result.TypeFQN)
+}
+
+func TestInferTypeFromStdlibFunctionWithArgs_GenericBindsFromArgType(t *testing.T) {
+	// hypothetical slices.Max[S ~[]E, E cmp.Ordered](s S) E
+	reg := core.NewGoModuleRegistry()
+	reg.StdlibLoader = &mockStdlibLoader{
+		stdlibPkgs: map[string]bool{"slices": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"slices.Max": {
+				Name:       "Max",
+				TypeParams: []core.GoTypeParam{{Name: "S"}, {Name: "E"}},
+				Params:     []string{"S"},
+				Returns:    []*core.GoReturnValue{{Type: "E"}},
+			},
+		},
+	}
+	argTypes := []*core.TypeInfo{{TypeFQN: "builtin.int"}}
+	result := inferTypeFromStdlibFunctionWithArgs("slices", "Max", argTypes, reg)
+	require.NotNil(t, result)
+	assert.Equal(t, "builtin.int", result.TypeFQN)
+	assert.Equal(t, "stdlib_registry", result.Source)
+}
+
+func TestInferTypeFromStdlibFunctionWithArgs_GenericUnresolvedWithoutArgs(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	reg.StdlibLoader = &mockStdlibLoader{
+		stdlibPkgs: map[string]bool{"slices": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"slices.Max": {
+				Name:       "Max",
+				TypeParams: []core.GoTypeParam{{Name: "S"}, {Name: "E"}},
+				Params:     []string{"S"},
+				Returns:    []*core.GoReturnValue{{Type: "E"}},
+			},
+		},
+	}
+	result := inferTypeFromStdlibFunctionWithArgs("slices", "Max", nil, reg)
+	require.NotNil(t, result)
+	assert.Equal(t, "<unresolved-generic>", result.TypeFQN)
+	assert.Less(t, float64(result.Confidence), 0.9)
+}
+
+// -----------------------------------------------------------------------------
+// InferTupleFromStdlibFunction
+// -----------------------------------------------------------------------------
+
+func TestInferTupleFromStdlibFunction_NilLoader(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	result := InferTupleFromStdlibFunction("io", "ReadAll", reg)
+	assert.Nil(t, result)
+}
+
+func TestInferTupleFromStdlibFunction_NotStdlib(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	reg.StdlibLoader = &mockStdlibLoader{
+		stdlibPkgs: map[string]bool{"io": true},
+	}
+	result := InferTupleFromStdlibFunction("github.com/myapp/utils", "ReadAll", reg)
+	assert.Nil(t, result)
+}
+
+func TestInferTupleFromStdlibFunction_FunctionNotFound(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	reg.StdlibLoader = &mockStdlibLoader{
+		stdlibPkgs: map[string]bool{"io": true},
+		functions:  map[string]*core.GoStdlibFunction{},
+	}
+	result := InferTupleFromStdlibFunction("io", "ReadAll", reg)
+	assert.Nil(t, result)
+}
+
+func TestInferTupleFromStdlibFunction_TwoTuple_ErrorPositionNil(t *testing.T) {
+	// io.ReadAll(r) ([]byte, error)
+	reg := core.NewGoModuleRegistry()
+	reg.StdlibLoader = &mockStdlibLoader{
+		stdlibPkgs: map[string]bool{"io": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"io.ReadAll": {
+				Name: "ReadAll",
+				Returns: []*core.GoReturnValue{
+					{Type: "[]byte"},
+					{Type: "error"},
+				},
+			},
+		},
+	}
+	result := InferTupleFromStdlibFunction("io", "ReadAll", reg)
+	require.Len(t, result, 2)
+	require.NotNil(t, result[0])
+	assert.Equal(t, "builtin.byte", result[0].TypeFQN)
+	assert.Nil(t, result[1], "error position should be nil")
+}
+
+func TestInferTupleFromStdlibFunction_ThreeTuple(t *testing.T) {
+	// hypothetical listener.Accept-style signature: (conn *Conn, addr string, err error)
+	reg := core.NewGoModuleRegistry()
+	reg.StdlibLoader = &mockStdlibLoader{
+		stdlibPkgs: map[string]bool{"net": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"net.Accept": {
+				Name: "Accept",
+				Returns: []*core.GoReturnValue{
+					{Type: "*Conn"},
+					{Type: "string"},
+					{Type: "error"},
+				},
+			},
+		},
+	}
+	result := InferTupleFromStdlibFunction("net", "Accept", reg)
+	require.Len(t, result, 3)
+	require.NotNil(t, result[0])
+	assert.Equal(t, "net.Conn", result[0].TypeFQN)
+	require.NotNil(t, result[1])
+	assert.Equal(t, "builtin.string", result[1].TypeFQN)
+	assert.Nil(t, result[2], "error position should be nil")
+}
+
+func TestInferTupleFromStdlibFunction_UnresolvedTypeParamPositionLeftNil(t *testing.T) {
+	// hypothetical two-value generic accessor: (value E, ok bool) with no
+	// call-site argument types available to unify E against.
+	reg := core.NewGoModuleRegistry()
+	reg.StdlibLoader = &mockStdlibLoader{
+		stdlibPkgs: map[string]bool{"sync": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"sync.Load": {
+				Name:       "Load",
+				TypeParams: []core.GoTypeParam{{Name: "E"}},
+				Returns: []*core.GoReturnValue{
+					{Type: "E"},
+					{Type: "bool"},
+				},
+			},
+		},
+	}
+	result := InferTupleFromStdlibFunction("sync", "Load", reg)
+	require.Len(t, result, 2)
+	assert.Nil(t, result[0], "unbound type parameter position should be nil, not builtin.E or sync.E")
+	require.NotNil(t, result[1])
+	assert.Equal(t, "builtin.bool", result[1].TypeFQN)
+}
+
 // -----------------------------------------------------------------------------
 // Integration: ExtractGoVariableAssignments with stdlib loader
 // -----------------------------------------------------------------------------
@@ -255,6 +455,107 @@ func Handler() {
 	assert.Equal(t, "stdlib_registry", bindings[0].Type.Source)
 }
 
+func TestExtractGoVariables_StdlibThreeTupleZipsEachPosition(t *testing.T) {
+	code := `package main
+
+import "net"
+
+func Listen() {
+	conn, addr, err := net.Accept()
+	_ = conn
+	_ = addr
+	_ = err
+}`
+
+	reg := &core.GoModuleRegistry{
+		ModulePath:  "test",
+		DirToImport: map[string]string{"/test": "test"},
+		StdlibLoader: &mockStdlibLoader{
+			stdlibPkgs: map[string]bool{"net": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"net.Accept": {
+					Name: "Accept",
+					Returns: []*core.GoReturnValue{
+						{Type: "*Conn"},
+						{Type: "string"},
+						{Type: "error"},
+					},
+				},
+			},
+		},
+	}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	importMap := &core.GoImportMap{
+		Imports: map[string]string{"net": "net"},
+	}
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, importMap)
+	require.NoError(t, err)
+
+	scope := typeEngine.GetScope("test.Listen")
+	require.NotNil(t, scope)
+
+	connBindings, ok := scope.Variables["conn"]
+	require.True(t, ok)
+	require.NotEmpty(t, connBindings)
+	assert.Equal(t, "net.Conn", connBindings[0].Type.TypeFQN)
+
+	addrBindings, ok := scope.Variables["addr"]
+	require.True(t, ok)
+	require.NotEmpty(t, addrBindings)
+	assert.Equal(t, "builtin.string", addrBindings[0].Type.TypeFQN)
+
+	// err has no useful concrete type (its Returns entry is "error"), so it
+	// should not get a binding at all — same as the existing `_`-discard tests.
+	if bindings, ok := scope.Variables["err"]; ok {
+		assert.Empty(t, bindings)
+	}
+}
+
+func TestExtractGoVariables_StdlibGenericUntypedCallSiteArgStaysUnresolved(t *testing.T) {
+	code := `package main
+
+import "slices"
+
+func Biggest(nums []int) {
+	m := slices.Max(nums)
+	_ = m
+}`
+
+	reg := &core.GoModuleRegistry{
+		ModulePath:  "test",
+		DirToImport: map[string]string{"/test": "test"},
+		StdlibLoader: &mockStdlibLoader{
+			stdlibPkgs: map[string]bool{"slices": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"slices.Max": {
+					Name:       "Max",
+					TypeParams: []core.GoTypeParam{{Name: "S"}, {Name: "E"}},
+					Params:     []string{"S"},
+					Returns:    []*core.GoReturnValue{{Type: "E"}},
+				},
+			},
+		},
+	}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	importMap := &core.GoImportMap{
+		Imports: map[string]string{"slices": "slices"},
+	}
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, importMap)
+	require.NoError(t, err)
+
+	scope := typeEngine.GetScope("test.Biggest")
+	require.NotNil(t, scope)
+	bindings, ok := scope.Variables["m"]
+	require.True(t, ok)
+	require.NotEmpty(t, bindings)
+	// "nums" itself isn't recognized as a typed parameter by this pass, so the
+	// argument type can't be inferred and the generic resolves to the
+	// unresolved-generic placeholder rather than a bound concrete type.
+	assert.Equal(t, "<unresolved-generic>", bindings[0].Type.TypeFQN)
+}
+
 func TestExtractGoVariables_StdlibNoLoader(t *testing.T) {
 	// Without a StdlibLoader, stdlib calls should leave variables untyped.
 	code := `package main
@@ -327,3 +628,698 @@ func Greet(name string) {
 	require.NotEmpty(t, bindings)
 	assert.Equal(t, "builtin.string", bindings[0].Type.TypeFQN)
 }
+
+// -----------------------------------------------------------------------------
+// resolveInterfaceMethodCall
+// -----------------------------------------------------------------------------
+
+func TestResolveInterfaceMethodCall_ResolvesViaCHA(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	reg.InterfaceMethods = map[string][]resolution.GoMethodSignature{
+		"github.com/example/shapes.Shape": {
+			{Name: "Area", Returns: []string{"float64"}},
+		},
+	}
+	reg.InterfaceImplementors = map[string][]string{
+		"github.com/example/shapes.Shape": {"github.com/example/shapes.Circle"},
+	}
+
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	scope := resolution.NewGoFunctionScope("test.Describe")
+	scope.AddVariable(&resolution.GoVariableBinding{
+		VarName: "s",
+		Type:    &core.TypeInfo{TypeFQN: "github.com/example/shapes.Shape"},
+	})
+	typeEngine.AddScope(scope)
+
+	operand := parseGoMethodCallOperand(t, "s", "Area")
+	result := resolveInterfaceMethodCall(operand, "s", "Area", "/test/main.go", "test.Describe", typeEngine, reg)
+	require.NotNil(t, result)
+	assert.Equal(t, "builtin.float64", result.TypeFQN)
+	assert.Equal(t, "cha", result.Source)
+	assert.Equal(t, []string{"github.com/example/shapes.Circle"}, result.Implementors)
+}
+
+func TestResolveInterfaceMethodCall_ConcurrentWithCHARebuildDoesNotRace(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shape.go"), []byte(`package shapes
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct{}
+
+func (c Circle) Area() float64 { return 0 }
+`), 0o644))
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{dir: "github.com/example/shapes"}
+
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	scope := resolution.NewGoFunctionScope("test.Describe")
+	scope.AddVariable(&resolution.GoVariableBinding{
+		VarName: "s",
+		Type:    &core.TypeInfo{TypeFQN: "github.com/example/shapes.Shape"},
+	})
+	typeEngine.AddScope(scope)
+
+	operand := parseGoMethodCallOperand(t, "s", "Area")
+
+	// One goroutine repeatedly rebuilds the CHA index while others
+	// concurrently read it through this package's entry points — run under
+	// `go test -race` to confirm extraction's reads take the same
+	// resolution.CHAMutex BuildGoInterfaceCHA's writes do.
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			resolution.BuildGoInterfaceCHA(reg)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			resolveInterfaceMethodCall(operand, "s", "Area", "/test/main.go", "test.Describe", typeEngine, reg)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			possibleTypesForInterface(&core.TypeInfo{TypeFQN: "github.com/example/shapes.Shape"}, reg)
+		}
+	}()
+	wg.Wait()
+}
+
+func TestResolveInterfaceMethodCall_VariableNotInterfaceTyped(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	reg.InterfaceMethods = map[string][]resolution.GoMethodSignature{
+		"github.com/example/shapes.Shape": {{Name: "Area", Returns: []string{"float64"}}},
+	}
+
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	scope := resolution.NewGoFunctionScope("test.Describe")
+	scope.AddVariable(&resolution.GoVariableBinding{
+		VarName: "s",
+		Type:    &core.TypeInfo{TypeFQN: "builtin.string"},
+	})
+	typeEngine.AddScope(scope)
+
+	operand := parseGoMethodCallOperand(t, "s", "Area")
+	assert.Nil(t, resolveInterfaceMethodCall(operand, "s", "Area", "/test/main.go", "test.Describe", typeEngine, reg))
+}
+
+func TestResolveInterfaceMethodCall_UnknownMethod(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	reg.InterfaceMethods = map[string][]resolution.GoMethodSignature{
+		"github.com/example/shapes.Shape": {{Name: "Area", Returns: []string{"float64"}}},
+	}
+
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	scope := resolution.NewGoFunctionScope("test.Describe")
+	scope.AddVariable(&resolution.GoVariableBinding{
+		VarName: "s",
+		Type:    &core.TypeInfo{TypeFQN: "github.com/example/shapes.Shape"},
+	})
+	typeEngine.AddScope(scope)
+
+	operand := parseGoMethodCallOperand(t, "s", "Perimeter")
+	assert.Nil(t, resolveInterfaceMethodCall(operand, "s", "Perimeter", "/test/main.go", "test.Describe", typeEngine, reg))
+}
+
+func TestResolveInterfaceMethodCall_NoCHAData(t *testing.T) {
+	reg := core.NewGoModuleRegistry()
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	operand := parseGoMethodCallOperand(t, "s", "Area")
+	assert.Nil(t, resolveInterfaceMethodCall(operand, "s", "Area", "/test/main.go", "test.Describe", typeEngine, reg))
+}
+
+// -----------------------------------------------------------------------------
+// Type assertions and type switches
+// -----------------------------------------------------------------------------
+
+func TestExtractGoVariables_TypeAssertion(t *testing.T) {
+	code := `package main
+
+func Handler(x interface{}) {
+	s := x.(string)
+	_ = s
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	scope := typeEngine.GetScope("test.Handler")
+	require.NotNil(t, scope)
+
+	bindings, ok := scope.Variables["s"]
+	require.True(t, ok)
+	require.NotEmpty(t, bindings)
+	assert.Equal(t, "builtin.string", bindings[0].Type.TypeFQN)
+	assert.Equal(t, "type_assertion", bindings[0].Type.Source)
+}
+
+func TestExtractGoVariables_TypeAssertionTwoValueFormOkIsBool(t *testing.T) {
+	code := `package main
+
+func Handler(x interface{}) {
+	s, ok := x.(string)
+	_ = s
+	_ = ok
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	scope := typeEngine.GetScope("test.Handler")
+	require.NotNil(t, scope)
+
+	sBindings, ok := scope.Variables["s"]
+	require.True(t, ok)
+	require.NotEmpty(t, sBindings)
+	assert.Equal(t, "builtin.string", sBindings[0].Type.TypeFQN)
+
+	okBindings, ok := scope.Variables["ok"]
+	require.True(t, ok)
+	require.NotEmpty(t, okBindings)
+	assert.Equal(t, "builtin.bool", okBindings[0].Type.TypeFQN)
+}
+
+func TestExtractGoVariables_TypeSwitchNarrowsPerCase(t *testing.T) {
+	code := `package main
+
+func Handler(x interface{}) {
+	switch v := x.(type) {
+	case string:
+		_ = v
+	case int:
+		_ = v
+	}
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	scope := typeEngine.GetScope("test.Handler")
+	require.NotNil(t, scope)
+
+	bindings, ok := scope.Variables["v"]
+	require.True(t, ok)
+	require.Len(t, bindings, 2)
+
+	var gotFQNs []string
+	for _, b := range bindings {
+		gotFQNs = append(gotFQNs, b.Type.TypeFQN)
+		assert.Equal(t, "type_switch", b.Type.Source)
+		assert.NotEmpty(t, b.BranchPath, "each case's binding should be scoped to its own branch")
+	}
+	assert.ElementsMatch(t, []string{"builtin.string", "builtin.int"}, gotFQNs)
+
+	// Each case's binding sits on its own branch, so neither should dominate
+	// a use-site in the other case (they're siblings, not predecessors).
+	assert.NotEqual(t, bindings[0].BranchPath, bindings[1].BranchPath)
+}
+
+func TestExtractGoVariables_TypeSwitchMultiTypeCaseLeavesGuardUnbound(t *testing.T) {
+	code := `package main
+
+func Handler(x interface{}) {
+	switch v := x.(type) {
+	case string, int:
+		_ = v
+	}
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	scope := typeEngine.GetScope("test.Handler")
+	require.NotNil(t, scope)
+	assert.Empty(t, scope.Variables["v"], "a multi-type case can't narrow v, so no binding should be recorded")
+}
+
+// -----------------------------------------------------------------------------
+// Range clause destructuring
+// -----------------------------------------------------------------------------
+
+func TestExtractGoVariables_RangeOverString(t *testing.T) {
+	code := `package main
+
+func Handler(s string) {
+	for i, r := range s {
+		_ = i
+		_ = r
+	}
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	scope := resolution.NewGoFunctionScope("test.Handler")
+	scope.AddVariable(&resolution.GoVariableBinding{VarName: "s", Type: &core.TypeInfo{TypeFQN: "builtin.string"}})
+	typeEngine.AddScope(scope)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	iBindings, ok := scope.Variables["i"]
+	require.True(t, ok)
+	require.NotEmpty(t, iBindings)
+	assert.Equal(t, "builtin.int", iBindings[0].Type.TypeFQN)
+
+	rBindings, ok := scope.Variables["r"]
+	require.True(t, ok)
+	require.NotEmpty(t, rBindings)
+	assert.Equal(t, "builtin.rune", rBindings[0].Type.TypeFQN)
+}
+
+func TestExtractGoVariables_RangeOverMapUsesKeyElem(t *testing.T) {
+	code := `package main
+
+func Handler(m map[string]int) {
+	for k, v := range m {
+		_ = k
+		_ = v
+	}
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	scope := resolution.NewGoFunctionScope("test.Handler")
+	scope.AddVariable(&resolution.GoVariableBinding{
+		VarName: "m",
+		Type:    &core.TypeInfo{TypeFQN: "map[string]int", Key: "builtin.string", Elem: "builtin.int"},
+	})
+	typeEngine.AddScope(scope)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	kBindings, ok := scope.Variables["k"]
+	require.True(t, ok)
+	require.NotEmpty(t, kBindings)
+	assert.Equal(t, "builtin.string", kBindings[0].Type.TypeFQN)
+
+	vBindings, ok := scope.Variables["v"]
+	require.True(t, ok)
+	require.NotEmpty(t, vBindings)
+	assert.Equal(t, "builtin.int", vBindings[0].Type.TypeFQN)
+}
+
+func TestExtractGoVariables_RangeOverSliceKeyIsInt(t *testing.T) {
+	code := `package main
+
+func Handler(xs []int) {
+	for i, v := range xs {
+		_ = i
+		_ = v
+	}
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	scope := resolution.NewGoFunctionScope("test.Handler")
+	scope.AddVariable(&resolution.GoVariableBinding{
+		VarName: "xs",
+		Type:    &core.TypeInfo{TypeFQN: "[]int", Elem: "builtin.int"},
+	})
+	typeEngine.AddScope(scope)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	iBindings, ok := scope.Variables["i"]
+	require.True(t, ok)
+	require.NotEmpty(t, iBindings)
+	assert.Equal(t, "builtin.int", iBindings[0].Type.TypeFQN)
+
+	vBindings, ok := scope.Variables["v"]
+	require.True(t, ok)
+	require.NotEmpty(t, vBindings)
+	assert.Equal(t, "builtin.int", vBindings[0].Type.TypeFQN)
+}
+
+func TestMapGoRangeKeyValueTypes_NoStructuralInfoReturnsNil(t *testing.T) {
+	key, value := mapGoRangeKeyValueTypes(&core.TypeInfo{TypeFQN: "pkg.User"})
+	assert.Nil(t, key)
+	assert.Nil(t, value)
+}
+
+// -----------------------------------------------------------------------------
+// ExtractGoVariableAssignmentsWithFacts
+// -----------------------------------------------------------------------------
+
+func TestExtractGoVariablesWithFacts_NilStoreBehavesLikePlainExtract(t *testing.T) {
+	code := `package main
+
+func Handler() {
+	s := "hi"
+	_ = s
+}`
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignmentsWithFacts("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{}, nil)
+	require.NoError(t, err)
+
+	bindings, ok := typeEngine.GetScope("test.Handler").Variables["s"]
+	require.True(t, ok)
+	assert.Equal(t, "builtin.string", bindings[0].Type.TypeFQN)
+}
+
+func TestExtractGoVariablesWithFacts_MissExtractsAndPersists(t *testing.T) {
+	code := `package main
+
+func Handler() {
+	s := "hi"
+	_ = s
+}`
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	store := resolution.NewFactStore(t.TempDir())
+
+	err := ExtractGoVariableAssignmentsWithFacts("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{}, store)
+	require.NoError(t, err)
+
+	bindings, ok := typeEngine.GetScope("test.Handler").Variables["s"]
+	require.True(t, ok)
+	assert.Equal(t, "builtin.string", bindings[0].Type.TypeFQN)
+
+	hash := resolution.ContentHash([]byte(code), nil)
+	assert.Contains(t, store.Functions(hash), "test.Handler")
+}
+
+func TestExtractGoVariablesWithFacts_HitSkipsReExtractionAndReusesCachedBinding(t *testing.T) {
+	code := `package main
+
+func Handler() {
+	s := "hi"
+	_ = s
+}`
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	store := resolution.NewFactStore(t.TempDir())
+
+	firstEngine := resolution.NewGoTypeInferenceEngine(reg)
+	require.NoError(t, ExtractGoVariableAssignmentsWithFacts("/test/main.go", []byte(code), firstEngine, reg, &core.GoImportMap{}, store))
+
+	// A fresh engine over the same unchanged source should get its binding
+	// straight from the fact store, without the file ever being reparsed.
+	secondEngine := resolution.NewGoTypeInferenceEngine(reg)
+	require.NoError(t, ExtractGoVariableAssignmentsWithFacts("/test/main.go", []byte(code), secondEngine, reg, &core.GoImportMap{}, store))
+
+	bindings, ok := secondEngine.GetScope("test.Handler").Variables["s"]
+	require.True(t, ok)
+	require.NotEmpty(t, bindings)
+	assert.Equal(t, "builtin.string", bindings[0].Type.TypeFQN)
+}
+
+func TestExtractGoVariables_FuncLiteralBindsSyntheticSignature(t *testing.T) {
+	code := `package main
+
+func Handler() {
+	greet := func(name string) string {
+		return name
+	}
+	_ = greet
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	bindings, ok := typeEngine.GetScope("test.Handler").Variables["greet"]
+	require.True(t, ok)
+	require.NotEmpty(t, bindings)
+
+	sig := bindings[0].Type.Signature
+	require.NotNil(t, sig)
+	assert.Equal(t, []string{"string"}, sig.Params)
+	assert.Equal(t, []string{"string"}, sig.Returns)
+	assert.Contains(t, bindings[0].Type.TypeFQN, "test.Handler.$func")
+}
+
+func TestExtractGoVariables_FuncLiteralBodyGetsOwnScope(t *testing.T) {
+	code := `package main
+
+func Handler() {
+	greet := func() string {
+		msg := "hi"
+		return msg
+	}
+	_ = greet
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	greetBindings := typeEngine.GetScope("test.Handler").Variables["greet"]
+	require.NotEmpty(t, greetBindings)
+	synthFQN := greetBindings[0].Type.TypeFQN
+
+	litScope := typeEngine.GetScope(synthFQN)
+	require.NotNil(t, litScope)
+	msgBindings, ok := litScope.Variables["msg"]
+	require.True(t, ok)
+	require.NotEmpty(t, msgBindings)
+	assert.Equal(t, "builtin.string", msgBindings[0].Type.TypeFQN)
+
+	// The literal's own body shouldn't leak "msg" into the enclosing scope.
+	_, leaked := typeEngine.GetScope("test.Handler").Variables["msg"]
+	assert.False(t, leaked)
+}
+
+func TestExtractGoVariables_CallThroughFuncLiteralVariableResolvesReturnType(t *testing.T) {
+	code := `package main
+
+func Handler() {
+	greet := func(name string) string {
+		return name
+	}
+	result := greet("hi")
+	_ = result
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	bindings, ok := typeEngine.GetScope("test.Handler").Variables["result"]
+	require.True(t, ok)
+	require.NotEmpty(t, bindings)
+	assert.Equal(t, "builtin.string", bindings[0].Type.TypeFQN)
+	assert.Equal(t, "func_literal_call", bindings[0].Type.Source)
+}
+
+func TestExtractGoVariables_TupleAssignmentThroughFuncLiteralVariableZipsEachPosition(t *testing.T) {
+	code := `package main
+
+func Handler() {
+	fetch := func(id string) (string, error) {
+		return id, nil
+	}
+	name, err := fetch("42")
+	_ = name
+	_ = err
+}`
+
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	nameBindings := typeEngine.GetScope("test.Handler").Variables["name"]
+	require.NotEmpty(t, nameBindings)
+	assert.Equal(t, "builtin.string", nameBindings[0].Type.TypeFQN)
+	assert.Equal(t, "func_literal_call", nameBindings[0].Type.Source)
+
+	errBindings := typeEngine.GetScope("test.Handler").Variables["err"]
+	require.NotEmpty(t, errBindings)
+	assert.Equal(t, "builtin.error", errBindings[0].Type.TypeFQN)
+}
+
+func TestExtractGoVariables_InterfaceTypedBindingGetsPossibleTypes(t *testing.T) {
+	code := `package test
+
+func Handler() {
+	s := GetShape()
+	_ = s
+}`
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	reg.InterfaceMethods = map[string][]resolution.GoMethodSignature{
+		"github.com/example/shapes.Shape": {{Name: "Area", Returns: []string{"float64"}}},
+	}
+	reg.InterfaceImplementors = map[string][]string{
+		"github.com/example/shapes.Shape": {"github.com/example/shapes.Circle"},
+	}
+
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	typeEngine.AddReturnType("test.GetShape", &core.TypeInfo{TypeFQN: "github.com/example/shapes.Shape", Confidence: 0.9})
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	bindings := typeEngine.GetScope("test.Handler").Variables["s"]
+	require.NotEmpty(t, bindings)
+	require.Len(t, bindings[0].PossibleTypes, 1)
+	assert.Equal(t, "github.com/example/shapes.Circle", bindings[0].PossibleTypes[0].TypeFQN)
+}
+
+func TestExtractGoVariables_ConcreteTypedBindingHasNoPossibleTypes(t *testing.T) {
+	code := `package main
+
+func Handler() {
+	s := "hi"
+	_ = s
+}`
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	bindings := typeEngine.GetScope("test.Handler").Variables["s"]
+	require.NotEmpty(t, bindings)
+	assert.Nil(t, bindings[0].PossibleTypes)
+}
+
+func TestExtractGoVariables_TupleAssignmentZipsLocalMultiReturn(t *testing.T) {
+	code := `package test
+
+func Handler() {
+	user, err := GetUser()
+	_ = user
+	_ = err
+}`
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	typeEngine.AddReturnTypes("test.GetUser", []*core.TypeInfo{
+		{TypeFQN: "test.User", Confidence: 0.9},
+		{TypeFQN: "builtin.error", Confidence: 0.9},
+	})
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	userBindings := typeEngine.GetScope("test.Handler").Variables["user"]
+	require.NotEmpty(t, userBindings)
+	assert.Equal(t, "test.User", userBindings[0].Type.TypeFQN)
+
+	errBindings := typeEngine.GetScope("test.Handler").Variables["err"]
+	require.NotEmpty(t, errBindings)
+	assert.Equal(t, "builtin.error", errBindings[0].Type.TypeFQN)
+}
+
+func TestExtractGoVariables_ReassignmentBlankIdentifierIsSkipped(t *testing.T) {
+	code := `package test
+
+func Handler() {
+	user, err := GetUser()
+	user, _ = GetUser()
+	_ = user
+	_ = err
+}`
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+	typeEngine.AddReturnTypes("test.GetUser", []*core.TypeInfo{
+		{TypeFQN: "test.User", Confidence: 0.9},
+		{TypeFQN: "builtin.error", Confidence: 0.9},
+	})
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	_, hasBlank := typeEngine.GetScope("test.Handler").Variables["_"]
+	assert.False(t, hasBlank)
+}
+
+// mockExtractionTypesChecker implements core.TypesChecker for testing
+// inferTypeFromRHS's go/types-first lookup, without a real go/types.Config.Check.
+type mockExtractionTypesChecker struct {
+	exprTypes map[string]*core.TypeInfo // key: "pkgPath.funcName.expr"
+}
+
+func (m *mockExtractionTypesChecker) FuncReturnType(pkgPath, funcName string) (*core.TypeInfo, bool) {
+	return nil, false
+}
+
+func (m *mockExtractionTypesChecker) ExpressionType(pkgPath, funcName, expr string, line int) (*core.TypeInfo, bool) {
+	info, ok := m.exprTypes[pkgPath+"."+funcName+"."+expr]
+	return info, ok
+}
+
+func (m *mockExtractionTypesChecker) MethodSet(pkgPath, typeName string) ([]string, bool) {
+	return nil, false
+}
+
+func (m *mockExtractionTypesChecker) Interfaces(pkgPath string) map[string][]string {
+	return nil
+}
+
+func TestExtractGoVariables_GoTypesExpressionTypeTakesPriorityOverPatternMatching(t *testing.T) {
+	code := `package test
+
+func Handler() {
+	x := 1
+	_ = x
+}`
+	reg := core.NewGoModuleRegistry()
+	reg.DirToImport = map[string]string{"/test": "test"}
+	reg.TypesChecker = &mockExtractionTypesChecker{
+		exprTypes: map[string]*core.TypeInfo{
+			// go/types says "1" resolved as an untyped constant default-typed to
+			// something other than the tree-sitter int_literal pattern's builtin.int,
+			// so a pass confirms the go/types answer won, not the pattern match.
+			"test.Handler.1": {TypeFQN: "builtin.int64", Confidence: 1.0, Source: "go/types"},
+		},
+	}
+	typeEngine := resolution.NewGoTypeInferenceEngine(reg)
+
+	err := ExtractGoVariableAssignments("/test/main.go", []byte(code), typeEngine, reg, &core.GoImportMap{})
+	require.NoError(t, err)
+
+	bindings, ok := typeEngine.GetScope("test.Handler").Variables["x"]
+	require.True(t, ok)
+	require.NotEmpty(t, bindings)
+	assert.Equal(t, "builtin.int64", bindings[0].Type.TypeFQN)
+	assert.Equal(t, "go/types", bindings[0].Type.Source)
+}
@@ -0,0 +1,171 @@
+package resolution
+
+import (
+	"fmt"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Diagnostic is one finding an Analyzer reports via Pass.Report, scoped to
+// the function the Pass was run for.
+type Diagnostic struct {
+	FunctionFQN string
+	Message     string
+}
+
+// Analyzer is one pluggable check over the type engine, modeled directly on
+// golang.org/x/tools/go/analysis.Analyzer: Name identifies it (the key other
+// analyzers reference in Requires, and the key its result is cached under),
+// Requires lists the analyzers whose results Run can read back via
+// Pass.ResultOf, and Run does the actual work, returning an arbitrary result
+// value later analyzers in the same run can depend on.
+type Analyzer struct {
+	Name     string
+	Requires []*Analyzer
+	Run      func(pass *Pass) (interface{}, error)
+}
+
+// Pass is what an Analyzer's Run receives for one function: the shared type
+// engine, the enclosing file's parsed AST and source, and a Report sink for
+// findings. ResultOf reads back a prerequisite analyzer's result, populated
+// by AnalyzerRunner before Run is called — only analyzers listed in this
+// Analyzer's own Requires are available.
+type Pass struct {
+	Engine      *GoTypeInferenceEngine
+	FunctionFQN string
+	File        *sitter.Node
+	SourceCode  []byte
+
+	// CallGraph is reserved for the call-graph builder's output once one
+	// exists in this tree — nothing under graph/callgraph constructs a call
+	// graph today, so this is left untyped rather than naming a type that
+	// doesn't exist anywhere on disk.
+	CallGraph interface{}
+
+	results map[string]interface{}
+	diags   *[]Diagnostic
+}
+
+// ResultOf returns the result a's Run previously returned in this same
+// AnalyzerRunner.Run call, or nil if a wasn't listed in the calling
+// Analyzer's Requires (and therefore wasn't run before it).
+func (p *Pass) ResultOf(a *Analyzer) interface{} {
+	if a == nil {
+		return nil
+	}
+	return p.results[a.Name]
+}
+
+// Report records one Diagnostic against the function this Pass was run for.
+func (p *Pass) Report(diag Diagnostic) {
+	*p.diags = append(*p.diags, diag)
+}
+
+// AnalyzerRunner runs a fixed set of Analyzers, in an order that respects
+// their Requires dependencies, caching each Analyzer's result per function
+// FQN so a later Run call for the same function (e.g. a second file pass, or
+// a downstream analyzer added after the fact) doesn't re-execute it.
+type AnalyzerRunner struct {
+	order []*Analyzer
+	// results[analyzerName][functionFQN] is the cached return value of that
+	// analyzer's Run for that function.
+	results map[string]map[string]interface{}
+}
+
+// NewAnalyzerRunner topologically sorts analyzers by their Requires edges
+// and returns a runner ready to execute them in that order. Returns an error
+// if Requires contains a cycle or references an analyzer not present in
+// analyzers — both caught once here, up front, rather than surfacing as a
+// nil-result surprise the first time Run is called.
+func NewAnalyzerRunner(analyzers []*Analyzer) (*AnalyzerRunner, error) {
+	order, err := topoSortAnalyzers(analyzers)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]map[string]interface{}, len(order))
+	for _, a := range order {
+		results[a.Name] = make(map[string]interface{})
+	}
+	return &AnalyzerRunner{order: order, results: results}, nil
+}
+
+// Run executes every analyzer in dependency order for one function, passing
+// each a Pass built from engine/file/sourceCode, and returns every
+// Diagnostic reported along the way. An analyzer whose Run returns an error
+// halts the run and that error is returned; analyzers already run for this
+// function keep their cached results regardless.
+func (r *AnalyzerRunner) Run(engine *GoTypeInferenceEngine, functionFQN string, file *sitter.Node, sourceCode []byte) ([]Diagnostic, error) {
+	var diags []Diagnostic
+	for _, a := range r.order {
+		if _, done := r.results[a.Name][functionFQN]; done {
+			continue
+		}
+		reqResults := make(map[string]interface{}, len(a.Requires))
+		for _, req := range a.Requires {
+			reqResults[req.Name] = r.results[req.Name][functionFQN]
+		}
+		pass := &Pass{
+			Engine:      engine,
+			FunctionFQN: functionFQN,
+			File:        file,
+			SourceCode:  sourceCode,
+			results:     reqResults,
+			diags:       &diags,
+		}
+		result, err := a.Run(pass)
+		if err != nil {
+			return diags, fmt.Errorf("analyzer %q: %w", a.Name, err)
+		}
+		r.results[a.Name][functionFQN] = result
+	}
+	return diags, nil
+}
+
+// topoSortAnalyzers orders analyzers so every Analyzer appears after every
+// entry in its own Requires, via a depth-first post-order visit — the same
+// approach golang.org/x/tools/go/analysis/internal/analysisflags uses to
+// order its Requires graph. Returns an error for a Requires cycle or a
+// Requires entry that isn't one of analyzers.
+func topoSortAnalyzers(analyzers []*Analyzer) ([]*Analyzer, error) {
+	byName := make(map[string]*Analyzer, len(analyzers))
+	for _, a := range analyzers {
+		byName[a.Name] = a
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(analyzers))
+	var order []*Analyzer
+
+	var visit func(a *Analyzer) error
+	visit = func(a *Analyzer) error {
+		switch state[a.Name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("analyzer %q participates in a Requires cycle", a.Name)
+		}
+		state[a.Name] = visiting
+		for _, req := range a.Requires {
+			if _, ok := byName[req.Name]; !ok {
+				return fmt.Errorf("analyzer %q requires %q, which is not in the analyzer set", a.Name, req.Name)
+			}
+			if err := visit(req); err != nil {
+				return err
+			}
+		}
+		state[a.Name] = visited
+		order = append(order, a)
+		return nil
+	}
+
+	for _, a := range analyzers {
+		if err := visit(a); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,118 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAnalyzerRunner_OrdersByRequires(t *testing.T) {
+	var ran []string
+	a := &Analyzer{Name: "a", Run: func(pass *Pass) (interface{}, error) {
+		ran = append(ran, "a")
+		return nil, nil
+	}}
+	b := &Analyzer{Name: "b", Requires: []*Analyzer{a}, Run: func(pass *Pass) (interface{}, error) {
+		ran = append(ran, "b")
+		return nil, nil
+	}}
+
+	runner, err := NewAnalyzerRunner([]*Analyzer{b, a})
+	require.NoError(t, err)
+
+	_, err = runner.Run(NewGoTypeInferenceEngine(nil), "test.F", nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b"}, ran)
+}
+
+func TestNewAnalyzerRunner_CycleIsRejected(t *testing.T) {
+	a := &Analyzer{Name: "a"}
+	b := &Analyzer{Name: "b", Requires: []*Analyzer{a}}
+	a.Requires = []*Analyzer{b}
+
+	_, err := NewAnalyzerRunner([]*Analyzer{a, b})
+	require.Error(t, err)
+}
+
+func TestNewAnalyzerRunner_UnknownRequirementIsRejected(t *testing.T) {
+	other := &Analyzer{Name: "other"}
+	a := &Analyzer{Name: "a", Requires: []*Analyzer{other}}
+
+	_, err := NewAnalyzerRunner([]*Analyzer{a})
+	require.Error(t, err)
+}
+
+func TestAnalyzerRunner_Run_PassesResultOfToDownstreamAnalyzer(t *testing.T) {
+	producer := &Analyzer{Name: "producer", Run: func(pass *Pass) (interface{}, error) {
+		return "produced-value", nil
+	}}
+	var consumed interface{}
+	consumer := &Analyzer{Name: "consumer", Requires: []*Analyzer{producer}, Run: func(pass *Pass) (interface{}, error) {
+		consumed = pass.ResultOf(producer)
+		return nil, nil
+	}}
+
+	runner, err := NewAnalyzerRunner([]*Analyzer{producer, consumer})
+	require.NoError(t, err)
+
+	_, err = runner.Run(NewGoTypeInferenceEngine(nil), "test.F", nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "produced-value", consumed)
+}
+
+func TestAnalyzerRunner_Run_CachesResultPerFunctionFQN(t *testing.T) {
+	runs := 0
+	a := &Analyzer{Name: "a", Run: func(pass *Pass) (interface{}, error) {
+		runs++
+		return nil, nil
+	}}
+	runner, err := NewAnalyzerRunner([]*Analyzer{a})
+	require.NoError(t, err)
+
+	_, err = runner.Run(NewGoTypeInferenceEngine(nil), "test.F", nil, nil)
+	require.NoError(t, err)
+	_, err = runner.Run(NewGoTypeInferenceEngine(nil), "test.F", nil, nil)
+	require.NoError(t, err)
+	_, err = runner.Run(NewGoTypeInferenceEngine(nil), "test.G", nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, runs, "same function FQN should hit the cache; a new FQN should not")
+}
+
+func TestAnalyzerRunner_Run_CollectsReportedDiagnostics(t *testing.T) {
+	a := &Analyzer{Name: "a", Run: func(pass *Pass) (interface{}, error) {
+		pass.Report(Diagnostic{FunctionFQN: pass.FunctionFQN, Message: "finding one"})
+		pass.Report(Diagnostic{FunctionFQN: pass.FunctionFQN, Message: "finding two"})
+		return nil, nil
+	}}
+	runner, err := NewAnalyzerRunner([]*Analyzer{a})
+	require.NoError(t, err)
+
+	diags, err := runner.Run(NewGoTypeInferenceEngine(nil), "test.F", nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, diags, 2)
+	assert.Equal(t, "test.F", diags[0].FunctionFQN)
+	assert.Equal(t, "finding one", diags[0].Message)
+}
+
+func TestAnalyzerRunner_Run_AnalyzerErrorHaltsRun(t *testing.T) {
+	a := &Analyzer{Name: "a", Run: func(pass *Pass) (interface{}, error) {
+		return nil, assert.AnError
+	}}
+	ranB := false
+	b := &Analyzer{Name: "b", Requires: []*Analyzer{a}, Run: func(pass *Pass) (interface{}, error) {
+		ranB = true
+		return nil, nil
+	}}
+	runner, err := NewAnalyzerRunner([]*Analyzer{a, b})
+	require.NoError(t, err)
+
+	_, err = runner.Run(NewGoTypeInferenceEngine(nil), "test.F", nil, nil)
+
+	require.Error(t, err)
+	assert.False(t, ranB, "an analyzer after a failing one should not run")
+}
@@ -0,0 +1,122 @@
+package resolution
+
+import (
+	"fmt"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// UnresolvedCallAnalyzer walks a function's scope for variable bindings
+// whose Type never resolved (GetReturnType/inference left TypeFQN empty, or
+// didn't bind it at all) — the scope-level symptom of a call this engine
+// couldn't resolve a return type for. Its result is the set of such
+// bindings themselves (not just their names), available to downstream
+// analyzers (e.g. ReceiverMismatchAnalyzer) via Pass.ResultOf so they can
+// skip exactly the unresolved binding without skipping every other binding
+// a reassigned variable of the same name picked up later.
+var UnresolvedCallAnalyzer = &Analyzer{
+	Name: "unresolved_call",
+	Run:  runUnresolvedCallAnalyzer,
+}
+
+func runUnresolvedCallAnalyzer(pass *Pass) (interface{}, error) {
+	scope := pass.Engine.GetScope(pass.FunctionFQN)
+	if scope == nil {
+		return nil, nil
+	}
+
+	var unresolved []*GoVariableBinding
+	for _, bindings := range scope.Variables {
+		for _, b := range bindings {
+			if b.Type != nil && b.Type.TypeFQN != "" {
+				continue
+			}
+			unresolved = append(unresolved, b)
+			pass.Report(Diagnostic{
+				FunctionFQN: pass.FunctionFQN,
+				Message:     fmt.Sprintf("variable %q (assigned from %q) has no resolvable type", b.VarName, b.AssignedFrom),
+			})
+		}
+	}
+	return unresolved, nil
+}
+
+// ReceiverMismatchAnalyzer flags an interface-typed binding whose recorded
+// PossibleTypes — the CHA implementor set captured at binding time (see
+// possibleTypesForInterface) — has drifted from the registry's current
+// InterfaceImplementors for that same interface. This catches bindings
+// created while CHA was still being built up incrementally: a binding made
+// before every implementing type had been discovered carries a stale,
+// too-small PossibleTypes that a call resolved against it (ResolveMethodCall)
+// would silently under-dispatch.
+//
+// Requires UnresolvedCallAnalyzer and skips exactly the bindings it already
+// flagged, since a binding with no resolved Type has nothing to compare
+// PossibleTypes against in the first place.
+var ReceiverMismatchAnalyzer = &Analyzer{
+	Name:     "receiver_mismatch",
+	Requires: []*Analyzer{UnresolvedCallAnalyzer},
+	Run:      runReceiverMismatchAnalyzer,
+}
+
+func runReceiverMismatchAnalyzer(pass *Pass) (interface{}, error) {
+	scope := pass.Engine.GetScope(pass.FunctionFQN)
+	if scope == nil || pass.Engine.Registry == nil {
+		return nil, nil
+	}
+
+	unresolved, _ := pass.ResultOf(UnresolvedCallAnalyzer).([]*GoVariableBinding)
+	alreadyFlagged := make(map[*GoVariableBinding]bool, len(unresolved))
+	for _, b := range unresolved {
+		alreadyFlagged[b] = true
+	}
+
+	var stale []*GoVariableBinding
+	for _, bindings := range scope.Variables {
+		for _, b := range bindings {
+			if alreadyFlagged[b] {
+				continue
+			}
+			if b.Type == nil || len(b.PossibleTypes) == 0 {
+				continue
+			}
+			CHAMutex.RLock()
+			current := pass.Engine.Registry.InterfaceImplementors[b.Type.TypeFQN]
+			CHAMutex.RUnlock()
+			if sameImplementorSet(current, b.PossibleTypes) {
+				continue
+			}
+			stale = append(stale, b)
+			pass.Report(Diagnostic{
+				FunctionFQN: pass.FunctionFQN,
+				Message: fmt.Sprintf(
+					"%q was bound against %d known implementor(s) of %s, but the registry's current set differs — re-run inference once CHA has fully converged",
+					b.VarName, len(b.PossibleTypes), b.Type.TypeFQN,
+				),
+			})
+		}
+	}
+	return stale, nil
+}
+
+// sameImplementorSet reports whether current (registry.InterfaceImplementors'
+// present-day answer) names exactly the same set of types as possible (a
+// binding's CHA snapshot at the time it was created), order ignored. A
+// length match alone isn't enough — two equally-sized sets can still differ
+// in membership if CHA both gained and lost an implementor between the
+// binding's creation and now.
+func sameImplementorSet(current []string, possible []*core.TypeInfo) bool {
+	if len(current) != len(possible) {
+		return false
+	}
+	currentSet := make(map[string]bool, len(current))
+	for _, fqn := range current {
+		currentSet[fqn] = true
+	}
+	for _, pt := range possible {
+		if pt == nil || !currentSet[pt.TypeFQN] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnresolvedCallAnalyzer_FlagsBindingWithNoType(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(nil)
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{VarName: "resp", Type: &core.TypeInfo{TypeFQN: "net/http.Response"}})
+	scope.AddVariable(&GoVariableBinding{VarName: "unknown", AssignedFrom: "test.doStuff", Type: nil})
+	engine.AddScope(scope)
+
+	runner, err := NewAnalyzerRunner([]*Analyzer{UnresolvedCallAnalyzer})
+	require.NoError(t, err)
+
+	diags, err := runner.Run(engine, "test.F", nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Message, "unknown")
+}
+
+func TestUnresolvedCallAnalyzer_NoScopeIsNotAnError(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(nil)
+	runner, err := NewAnalyzerRunner([]*Analyzer{UnresolvedCallAnalyzer})
+	require.NoError(t, err)
+
+	diags, err := runner.Run(engine, "test.Missing", nil, nil)
+
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+}
+
+func TestReceiverMismatchAnalyzer_FlagsDriftedPossibleTypes(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		InterfaceImplementors: map[string][]string{
+			"test.Shape": {"test.Circle", "test.Square", "test.Triangle"},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{
+		VarName: "s",
+		Type:    &core.TypeInfo{TypeFQN: "test.Shape"},
+		// Bound back when CHA only knew about two implementors; the registry
+		// has since discovered a third.
+		PossibleTypes: []*core.TypeInfo{
+			{TypeFQN: "test.Circle"},
+			{TypeFQN: "test.Square"},
+		},
+	})
+	engine.AddScope(scope)
+
+	runner, err := NewAnalyzerRunner([]*Analyzer{UnresolvedCallAnalyzer, ReceiverMismatchAnalyzer})
+	require.NoError(t, err)
+
+	diags, err := runner.Run(engine, "test.F", nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Message, "s")
+	assert.Contains(t, diags[0].Message, "test.Shape")
+}
+
+func TestReceiverMismatchAnalyzer_MatchingPossibleTypesIsClean(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		InterfaceImplementors: map[string][]string{
+			"test.Shape": {"test.Circle", "test.Square"},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{
+		VarName: "s",
+		Type:    &core.TypeInfo{TypeFQN: "test.Shape"},
+		PossibleTypes: []*core.TypeInfo{
+			{TypeFQN: "test.Circle"},
+			{TypeFQN: "test.Square"},
+		},
+	})
+	engine.AddScope(scope)
+
+	runner, err := NewAnalyzerRunner([]*Analyzer{UnresolvedCallAnalyzer, ReceiverMismatchAnalyzer})
+	require.NoError(t, err)
+
+	diags, err := runner.Run(engine, "test.F", nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+}
+
+func TestReceiverMismatchAnalyzer_SkipsVariableAlreadyFlaggedUnresolved(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		InterfaceImplementors: map[string][]string{
+			"test.Shape": {"test.Circle", "test.Square", "test.Triangle"},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+	scope := NewGoFunctionScope("test.F")
+	// No Type at all — UnresolvedCallAnalyzer flags it first; it has no Type
+	// to compare PossibleTypes against, so ReceiverMismatchAnalyzer must not
+	// also report it.
+	scope.AddVariable(&GoVariableBinding{
+		VarName: "s",
+		PossibleTypes: []*core.TypeInfo{
+			{TypeFQN: "test.Circle"},
+		},
+	})
+	engine.AddScope(scope)
+
+	runner, err := NewAnalyzerRunner([]*Analyzer{UnresolvedCallAnalyzer, ReceiverMismatchAnalyzer})
+	require.NoError(t, err)
+
+	diags, err := runner.Run(engine, "test.F", nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Message, "no resolvable type")
+}
+
+func TestReceiverMismatchAnalyzer_ReassignedVariableStillChecksLaterBinding(t *testing.T) {
+	// "s" is assigned twice: first from an unresolved call (no Type at all),
+	// then reassigned to an interface-typed value whose PossibleTypes has
+	// gone stale. UnresolvedCallAnalyzer flags the first binding only — the
+	// second must still be checked, not skipped just because they share a
+	// variable name.
+	reg := &core.GoModuleRegistry{
+		InterfaceImplementors: map[string][]string{
+			"test.Shape": {"test.Circle", "test.Square", "test.Triangle"},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{VarName: "s", StartByte: 10, AssignedFrom: "test.doStuff"})
+	scope.AddVariable(&GoVariableBinding{
+		VarName:   "s",
+		StartByte: 20,
+		Type:      &core.TypeInfo{TypeFQN: "test.Shape"},
+		PossibleTypes: []*core.TypeInfo{
+			{TypeFQN: "test.Circle"},
+			{TypeFQN: "test.Square"},
+		},
+	})
+	engine.AddScope(scope)
+
+	runner, err := NewAnalyzerRunner([]*Analyzer{UnresolvedCallAnalyzer, ReceiverMismatchAnalyzer})
+	require.NoError(t, err)
+
+	diags, err := runner.Run(engine, "test.F", nil, nil)
+	require.NoError(t, err)
+
+	require.Len(t, diags, 2, "one diagnostic for the unresolved first binding, one for the stale second binding")
+	messages := []string{diags[0].Message, diags[1].Message}
+	assert.Contains(t, messages[0]+messages[1], "no resolvable type")
+	assert.Contains(t, messages[0]+messages[1], "test.Shape")
+}
+
+func TestSameImplementorSet_SameSizeDifferentMembersIsNotEqual(t *testing.T) {
+	current := []string{"test.Circle", "test.Triangle"}
+	possible := []*core.TypeInfo{{TypeFQN: "test.Circle"}, {TypeFQN: "test.Square"}}
+
+	assert.False(t, sameImplementorSet(current, possible))
+}
@@ -0,0 +1,232 @@
+package resolution
+
+import (
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// DefaultGoBuildContext returns a core.BuildContext describing the toolchain
+// actually running this process (runtime.GOOS/runtime.GOARCH, no extra
+// tags). ExtractGoImports falls back to this when the registry it's given
+// has no BuildContext of its own set, so callers that never opted into
+// build-constraint filtering keep seeing every import exactly as before.
+func DefaultGoBuildContext() core.BuildContext {
+	return core.BuildContext{GOOS: runtime.GOOS, GOARCH: runtime.GOARCH}
+}
+
+// goKnownGOOS and goKnownGOARCH list the suffix values the implicit
+// filename build-constraint rule recognises (see
+// https://pkg.go.dev/cmd/go#hdr-Build_constraints). A trailing path segment
+// that isn't in one of these sets is an ordinary identifier, not an
+// OS/arch constraint (e.g. "server_v2.go" is not arch-constrained).
+var goKnownGOOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true,
+	"freebsd": true, "hurd": true, "illumos": true, "ios": true,
+	"js": true, "linux": true, "netbsd": true, "openbsd": true,
+	"plan9": true, "solaris": true, "wasip1": true, "windows": true,
+}
+
+var goKnownGOARCH = map[string]bool{
+	"386": true, "amd64": true, "arm": true, "arm64": true,
+	"loong64": true, "mips": true, "mips64": true, "mips64le": true,
+	"mipsle": true, "ppc64": true, "ppc64le": true, "riscv64": true,
+	"s390x": true, "wasm": true,
+}
+
+// ImpliedTagsFromFilename infers the build tags implied by filePath's own
+// name, per the standard filename-suffix rule: "_GOOS.go", "_GOARCH.go", and
+// "_GOOS_GOARCH.go" constrain the file to that OS and/or architecture, and
+// "_test.go" marks it a test file. Returns nil when the filename implies no
+// constraint.
+func ImpliedTagsFromFilename(filePath string) []string {
+	base := strings.TrimSuffix(filepath.Base(filePath), ".go")
+	parts := strings.Split(base, "_")
+	if len(parts) < 2 {
+		return nil
+	}
+
+	var tags []string
+	if parts[len(parts)-1] == "test" {
+		tags = append(tags, "test")
+		parts = parts[:len(parts)-1]
+	}
+
+	switch {
+	case len(parts) >= 3 && goKnownGOOS[parts[len(parts)-2]] && goKnownGOARCH[parts[len(parts)-1]]:
+		tags = append(tags, parts[len(parts)-2], parts[len(parts)-1])
+	case len(parts) >= 2 && (goKnownGOOS[parts[len(parts)-1]] || goKnownGOARCH[parts[len(parts)-1]]):
+		tags = append(tags, parts[len(parts)-1])
+	}
+	return tags
+}
+
+// goBuildTagSatisfied is a closure over a BuildContext's satisfied tag set,
+// returned by parsing a //go:build expression or a legacy +build line.
+type goBuildTagSatisfied func(satisfied map[string]bool) bool
+
+// goBuildExprTokenRegex tokenizes a //go:build expression into identifiers,
+// parens, "!", "&&", and "||".
+var goBuildExprTokenRegex = regexp.MustCompile(`\(|\)|!|&&|\|\||[^\s()!&|]+`)
+
+// goBuildExprParser is a small recursive-descent parser for the //go:build
+// boolean grammar (precedence, low to high: ||, &&, !, with parens
+// overriding).
+type goBuildExprParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseGoBuildExpr(expr string) goBuildTagSatisfied {
+	p := &goBuildExprParser{tokens: goBuildExprTokenRegex.FindAllString(expr, -1)}
+	return p.parseOr()
+}
+
+func (p *goBuildExprParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *goBuildExprParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *goBuildExprParser) parseOr() goBuildTagSatisfied {
+	left := p.parseAnd()
+	for p.peek() == "||" {
+		p.next()
+		right := p.parseAnd()
+		prevLeft := left
+		left = func(s map[string]bool) bool { return prevLeft(s) || right(s) }
+	}
+	return left
+}
+
+func (p *goBuildExprParser) parseAnd() goBuildTagSatisfied {
+	left := p.parseUnary()
+	for p.peek() == "&&" {
+		p.next()
+		right := p.parseUnary()
+		prevLeft := left
+		left = func(s map[string]bool) bool { return prevLeft(s) && right(s) }
+	}
+	return left
+}
+
+func (p *goBuildExprParser) parseUnary() goBuildTagSatisfied {
+	switch p.peek() {
+	case "!":
+		p.next()
+		inner := p.parseUnary()
+		return func(s map[string]bool) bool { return !inner(s) }
+	case "(":
+		p.next()
+		inner := p.parseOr()
+		if p.peek() == ")" {
+			p.next()
+		}
+		return inner
+	default:
+		tag := p.next()
+		return func(s map[string]bool) bool { return s[tag] }
+	}
+}
+
+// parseLegacyBuildTags parses a legacy `// +build ...` line: space-separated
+// OR terms, each a comma-separated AND of (possibly "!"-negated) tags.
+func parseLegacyBuildTags(line string) goBuildTagSatisfied {
+	var orTerms []goBuildTagSatisfied
+	for _, field := range strings.Fields(line) {
+		var andTags []goBuildTagSatisfied
+		for _, term := range strings.Split(field, ",") {
+			negated := strings.HasPrefix(term, "!")
+			tag := strings.TrimPrefix(term, "!")
+			andTags = append(andTags, func(s map[string]bool) bool {
+				if negated {
+					return !s[tag]
+				}
+				return s[tag]
+			})
+		}
+		orTerms = append(orTerms, func(s map[string]bool) bool {
+			for _, fn := range andTags {
+				if !fn(s) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	return func(s map[string]bool) bool {
+		for _, fn := range orTerms {
+			if fn(s) {
+				return true
+			}
+		}
+		return len(orTerms) == 0
+	}
+}
+
+// buildSatisfiedTagSet returns the set of tags a BuildContext satisfies for
+// purposes of evaluating //go:build / +build expressions: its GOOS, its
+// GOARCH, and every explicit tag. "test" is always satisfied regardless of
+// ctx — this tool analyses test files as part of the call graph the same as
+// any other source file, so the _test.go filename-suffix rule (see
+// ImpliedTagsFromFilename) never excludes a file, only contributes metadata.
+func buildSatisfiedTagSet(ctx core.BuildContext) map[string]bool {
+	satisfied := map[string]bool{ctx.GOOS: true, ctx.GOARCH: true, "test": true}
+	for _, tag := range ctx.Tags {
+		satisfied[tag] = true
+	}
+	return satisfied
+}
+
+// EvaluateGoBuildConstraints reports whether filePath's source would be
+// included in a build under ctx: every //go:build expression and every
+// legacy +build line found in the file's leading comments must hold, and —
+// other than the exempted "test" tag — every OS/arch implied by the
+// filename itself (see ImpliedTagsFromFilename) must match ctx too.
+func EvaluateGoBuildConstraints(filePath string, sourceCode []byte, ctx core.BuildContext) bool {
+	satisfied := buildSatisfiedTagSet(ctx)
+
+	for _, tag := range ImpliedTagsFromFilename(filePath) {
+		if tag == "test" {
+			continue
+		}
+		if !satisfied[tag] {
+			return false
+		}
+	}
+
+	for _, rawLine := range strings.Split(string(sourceCode), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "//") {
+			// Reached the package clause (or other code) — no more
+			// leading-comment build constraints can follow.
+			break
+		}
+
+		switch {
+		case strings.HasPrefix(line, "//go:build "):
+			if !parseGoBuildExpr(strings.TrimPrefix(line, "//go:build "))(satisfied) {
+				return false
+			}
+		case strings.HasPrefix(line, "// +build "):
+			if !parseLegacyBuildTags(strings.TrimPrefix(line, "// +build "))(satisfied) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
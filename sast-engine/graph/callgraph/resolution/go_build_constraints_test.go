@@ -0,0 +1,142 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpliedTagsFromFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "plain file", path: "server.go", want: nil},
+		{name: "goos suffix", path: "net_linux.go", want: []string{"linux"}},
+		{name: "goarch suffix", path: "net_amd64.go", want: []string{"amd64"}},
+		{name: "goos and goarch suffix", path: "net_linux_amd64.go", want: []string{"linux", "amd64"}},
+		{name: "test suffix", path: "net_test.go", want: []string{"test"}},
+		{name: "test suffix with goos", path: "net_linux_test.go", want: []string{"test", "linux"}},
+		{name: "non-os-arch trailing word", path: "server_v2.go", want: nil},
+		{name: "full path", path: "/tmp/pkg/net_windows.go", want: []string{"windows"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ImpliedTagsFromFilename(tt.path))
+		})
+	}
+}
+
+func TestEvaluateGoBuildConstraints_GoBuildLine(t *testing.T) {
+	ctx := core.BuildContext{GOOS: "linux", GOARCH: "amd64"}
+
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("//go:build linux\n\npackage net\n"), ctx))
+	assert.False(t, EvaluateGoBuildConstraints("net.go", []byte("//go:build windows\n\npackage net\n"), ctx))
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("//go:build linux && amd64\n\npackage net\n"), ctx))
+	assert.False(t, EvaluateGoBuildConstraints("net.go", []byte("//go:build linux && arm64\n\npackage net\n"), ctx))
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("//go:build windows || linux\n\npackage net\n"), ctx))
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("//go:build !windows\n\npackage net\n"), ctx))
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("//go:build (linux && amd64) || darwin\n\npackage net\n"), ctx))
+}
+
+func TestEvaluateGoBuildConstraints_LegacyBuildLine(t *testing.T) {
+	ctx := core.BuildContext{GOOS: "linux", GOARCH: "amd64"}
+
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("// +build linux\n\npackage net\n"), ctx))
+	assert.False(t, EvaluateGoBuildConstraints("net.go", []byte("// +build windows\n\npackage net\n"), ctx))
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("// +build linux,amd64\n\npackage net\n"), ctx))
+	assert.False(t, EvaluateGoBuildConstraints("net.go", []byte("// +build linux,arm64\n\npackage net\n"), ctx))
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("// +build windows darwin linux\n\npackage net\n"), ctx))
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte("// +build !windows\n\npackage net\n"), ctx))
+
+	// Multiple +build lines are ANDed together.
+	multi := "// +build linux\n// +build amd64\n\npackage net\n"
+	assert.True(t, EvaluateGoBuildConstraints("net.go", []byte(multi), ctx))
+	multiFail := "// +build linux\n// +build arm64\n\npackage net\n"
+	assert.False(t, EvaluateGoBuildConstraints("net.go", []byte(multiFail), ctx))
+}
+
+func TestEvaluateGoBuildConstraints_FilenameSuffix(t *testing.T) {
+	ctx := core.BuildContext{GOOS: "linux", GOARCH: "amd64"}
+	src := []byte("package net\n")
+
+	assert.True(t, EvaluateGoBuildConstraints("net_linux.go", src, ctx))
+	assert.False(t, EvaluateGoBuildConstraints("net_windows.go", src, ctx))
+	assert.True(t, EvaluateGoBuildConstraints("net_linux_amd64.go", src, ctx))
+	assert.False(t, EvaluateGoBuildConstraints("net_linux_arm64.go", src, ctx))
+	// _test.go exempts only the test tag; the windows suffix still excludes
+	// the file under linux.
+	assert.False(t, EvaluateGoBuildConstraints("net_windows_test.go", src, ctx))
+}
+
+func TestEvaluateGoBuildConstraints_ConstraintMustPrecedePackageClause(t *testing.T) {
+	ctx := core.BuildContext{GOOS: "linux", GOARCH: "amd64"}
+	src := []byte("package net\n\n//go:build windows\n")
+
+	assert.True(t, EvaluateGoBuildConstraints("net.go", src, ctx), "a //go:build comment after the package clause is not a build constraint")
+}
+
+func TestDefaultGoBuildContext(t *testing.T) {
+	ctx := DefaultGoBuildContext()
+	assert.NotEmpty(t, ctx.GOOS)
+	assert.NotEmpty(t, ctx.GOARCH)
+	assert.Empty(t, ctx.Tags)
+}
+
+func TestExtractGoImports_BuildConstraintMarksImportsConstrained(t *testing.T) {
+	registry := &core.GoModuleRegistry{
+		ModulePath:     "github.com/example/test",
+		DirToImport:    make(map[string]string),
+		ImportToDir:    make(map[string]string),
+		StdlibPackages: make(map[string]bool),
+		BuildContext:   &core.BuildContext{GOOS: "linux", GOARCH: "amd64"},
+	}
+
+	source := `//go:build windows
+
+package net
+
+import "fmt"
+`
+	importMap, err := ExtractGoImports("/tmp/net.go", []byte(source), registry)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"fmt": "fmt"}, importMap.Imports)
+	assert.True(t, importMap.Constrained["fmt"])
+}
+
+func TestExtractGoImports_SatisfiedBuildConstraintLeavesImportsUnconstrained(t *testing.T) {
+	registry := &core.GoModuleRegistry{
+		ModulePath:     "github.com/example/test",
+		DirToImport:    make(map[string]string),
+		ImportToDir:    make(map[string]string),
+		StdlibPackages: make(map[string]bool),
+		BuildContext:   &core.BuildContext{GOOS: "linux", GOARCH: "amd64"},
+	}
+
+	source := `//go:build linux
+
+package net
+
+import "fmt"
+`
+	importMap, err := ExtractGoImports("/tmp/net.go", []byte(source), registry)
+	require.NoError(t, err)
+	assert.Empty(t, importMap.Constrained)
+}
+
+func TestExtractGoImports_NoBuildContextDefaultsToRuntime(t *testing.T) {
+	registry := &core.GoModuleRegistry{
+		ModulePath:     "github.com/example/test",
+		DirToImport:    make(map[string]string),
+		ImportToDir:    make(map[string]string),
+		StdlibPackages: make(map[string]bool),
+	}
+
+	importMap, err := ExtractGoImports("/tmp/plain.go", []byte("package net\n\nimport \"fmt\"\n"), registry)
+	require.NoError(t, err)
+	assert.Empty(t, importMap.Constrained)
+}
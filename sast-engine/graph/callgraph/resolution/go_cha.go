@@ -0,0 +1,373 @@
+package resolution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	sitter "github.com/smacker/go-tree-sitter"
+	golang "github.com/smacker/go-tree-sitter/golang"
+)
+
+// CHAMutex guards registry.TypeMethods, InterfaceMethods,
+// InterfaceImplementors, and TypeInterfaces — the four CHA-derived fields
+// BuildGoInterfaceCHA writes and ResolveMethodCall reads. They live on the
+// external core.GoModuleRegistry type, which has no mutex of its own to
+// embed, so the lock lives here instead, at package scope — but it's also
+// read directly from other packages (e.g. extraction.possibleTypesForInterface
+// and extraction.resolveInterfaceMethodCall) wherever they touch these same
+// four fields, and from this package's own analyzers (see
+// go_analyzers.go's runReceiverMismatchAnalyzer), so it's exported rather
+// than kept package-private: every reader and writer of these fields, in
+// every package, takes CHAMutex, the same RWMutex pattern the engine's own
+// ifaceMutex already uses for its separate go/types-backed index. That
+// makes a CHA rebuild safe to run alongside any of those concurrent reads
+// instead of racing on plain maps.
+var CHAMutex sync.RWMutex
+
+// GoMethodSignature is a single method's structural signature: its name plus
+// its parameter and return type expressions exactly as written in source.
+// BuildGoInterfaceCHA compares these textually rather than via go/types —
+// this module parses with tree-sitter and has no type-checked AST to consult
+// — so two methods only match when their declared type expressions are
+// spelled identically (e.g. an interface requiring "io.Writer" won't match a
+// concrete method declared as just "Writer" within the same package as
+// io.Writer's own definition). That's a known approximation, not a bug: CHA
+// is meant to over-approximate possible dispatch targets, and a spurious
+// miss here only means a real implementor isn't recognised — it never
+// fabricates one that doesn't exist.
+type GoMethodSignature struct {
+	Name    string
+	Params  []string
+	Returns []string
+
+	// PointerRecv records whether this method was declared on a pointer
+	// receiver. Unused by methodSetSatisfiesStructurally today — Go's own
+	// value/pointer method-set distinction matters for *addressability* of
+	// the receiver at the call site, which this structural pass has no way
+	// to observe — but recorded per the CHA request's "pointer/value
+	// distinction recorded" ask, for a future caller that does have that
+	// context.
+	PointerRecv bool
+}
+
+// BuildGoInterfaceCHA performs a Class Hierarchy Analysis pass over every
+// non-test .go file reachable from registry.DirToImport: it collects each
+// concrete type's method set (from method_declaration receivers) and each
+// interface's required method set (from interface_type declarations), then
+// computes, for every (interface, concrete type) pair, whether the concrete
+// type's method set structurally satisfies the interface's (see
+// methodSetSatisfiesStructurally).
+//
+// Results are stored on registry.InterfaceImplementors (interface FQN →
+// implementor FQNs) and registry.TypeInterfaces (concrete FQN → interfaces
+// it satisfies) — the tree-sitter-only counterpart to
+// GoTypeInferenceEngine.ImplementorsOf/InterfacesFor, which require a
+// TypesChecker. GoImportResolver callers don't need this; it exists so
+// extraction.inferTypeFromFunctionCall can resolve an interface-typed
+// variable's method call to the concrete types that might actually run,
+// without a go/types dependency.
+//
+// A directory that can't be read, or a file that fails to parse, is skipped
+// rather than failing the whole pass — the same best-effort degradation
+// BuildGoModuleRegistry already applies to optional registry data.
+//
+// Thread safety: the whole pass runs sequentially and still replaces all
+// four result fields in one bulk assignment — it is not an incremental,
+// mergeable build. What CHAMutex does guarantee is that assignment is
+// race-free against a concurrent ResolveMethodCall, so a background re-run
+// of this pass is safe to overlap with lookups already in flight.
+func BuildGoInterfaceCHA(registry *core.GoModuleRegistry) {
+	if registry == nil {
+		return
+	}
+
+	typeMethods := make(map[string][]GoMethodSignature)
+	interfaceMethods := make(map[string][]GoMethodSignature)
+
+	for dir, importPath := range registry.DirToImport {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			source, readErr := os.ReadFile(filepath.Join(dir, name))
+			if readErr != nil {
+				continue
+			}
+			collectGoCHADeclarations(source, importPath, typeMethods, interfaceMethods)
+		}
+	}
+
+	implementors, ifaces := computeGoInterfaceSatisfaction(typeMethods, interfaceMethods)
+
+	CHAMutex.Lock()
+	registry.TypeMethods = typeMethods
+	registry.InterfaceMethods = interfaceMethods
+	registry.InterfaceImplementors = implementors
+	registry.TypeInterfaces = ifaces
+	CHAMutex.Unlock()
+}
+
+// ResolveMethodCall returns the fully-qualified method(s) that a call to
+// methodName on a value of type recvType could actually dispatch to. For a
+// concrete receiver this is always exactly one FQN:
+// recvType.TypeFQN+"."+methodName. For an interface receiver it consults
+// registry.InterfaceImplementors (populated by BuildGoInterfaceCHA) and
+// returns "implementorFQN.methodName" for every registered implementor —
+// the static type's own method declaration is never returned for an
+// interface, since interfaces have no method bodies of their own to
+// dispatch to.
+//
+// Returns nil for a nil recvType or an interface with no known implementors
+// (BuildGoInterfaceCHA not yet run, or a genuinely unimplemented interface);
+// callers should treat that the same as "can't resolve this call" rather
+// than as an error.
+func (e *GoTypeInferenceEngine) ResolveMethodCall(recvType *core.TypeInfo, methodName string) []string {
+	if recvType == nil || recvType.TypeFQN == "" || methodName == "" {
+		return nil
+	}
+
+	if e.Registry == nil {
+		return []string{recvType.TypeFQN + "." + methodName}
+	}
+
+	CHAMutex.RLock()
+	requiredMethods, isInterface := e.Registry.InterfaceMethods[recvType.TypeFQN]
+	concreteTypes := e.Registry.InterfaceImplementors[recvType.TypeFQN]
+	CHAMutex.RUnlock()
+
+	if !isInterface || len(requiredMethods) == 0 {
+		return []string{recvType.TypeFQN + "." + methodName}
+	}
+
+	if len(concreteTypes) == 0 {
+		return nil
+	}
+	calls := make([]string, 0, len(concreteTypes))
+	for _, concreteFQN := range concreteTypes {
+		calls = append(calls, concreteFQN+"."+methodName)
+	}
+	return calls
+}
+
+// computeGoInterfaceSatisfaction checks every (interface, concrete type)
+// pair for structural method-set satisfaction, returning both the interface
+// → implementors index and its reverse, concrete type → satisfied
+// interfaces.
+func computeGoInterfaceSatisfaction(typeMethods, interfaceMethods map[string][]GoMethodSignature) (map[string][]string, map[string][]string) {
+	implementors := make(map[string][]string)
+	ifaces := make(map[string][]string)
+
+	for ifaceFQN, required := range interfaceMethods {
+		if len(required) == 0 {
+			continue
+		}
+		for typeFQN, methods := range typeMethods {
+			if len(methods) == 0 || !methodSetSatisfiesStructurally(methods, required) {
+				continue
+			}
+			implementors[ifaceFQN] = append(implementors[ifaceFQN], typeFQN)
+			ifaces[typeFQN] = append(ifaces[typeFQN], ifaceFQN)
+		}
+	}
+	return implementors, ifaces
+}
+
+// methodSetSatisfiesStructurally reports whether methods (a concrete type's
+// full method set) contains a match for every entry in required (an
+// interface's method set) — same name, same parameter types, same return
+// types, in order.
+func methodSetSatisfiesStructurally(methods, required []GoMethodSignature) bool {
+	for _, req := range required {
+		if !hasMatchingGoMethod(methods, req) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasMatchingGoMethod(methods []GoMethodSignature, required GoMethodSignature) bool {
+	for _, m := range methods {
+		if m.Name == required.Name &&
+			goTypeStringsEqual(m.Params, required.Params) &&
+			goTypeStringsEqual(m.Returns, required.Returns) {
+			return true
+		}
+	}
+	return false
+}
+
+func goTypeStringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// collectGoCHADeclarations parses source with tree-sitter and folds every
+// top-level method_declaration into typeMethods (keyed by
+// "importPath.ReceiverType") and every interface type_declaration into
+// interfaceMethods (keyed by "importPath.InterfaceName").
+func collectGoCHADeclarations(source []byte, importPath string, typeMethods, interfaceMethods map[string][]GoMethodSignature) {
+	parser := sitter.NewParser()
+	parser.SetLanguage(golang.GetLanguage())
+	defer parser.Close()
+
+	tree, err := parser.ParseCtx(context.Background(), nil, source)
+	if err != nil {
+		return
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		child := root.NamedChild(i)
+		switch child.Type() {
+		case "method_declaration":
+			collectGoConcreteMethod(child, source, importPath, typeMethods)
+		case "type_declaration":
+			collectGoTypeSpecs(child, source, importPath, interfaceMethods)
+		}
+	}
+}
+
+// collectGoConcreteMethod records node (a top-level method_declaration) in
+// typeMethods, keyed by its receiver's type FQN. A method_declaration with no
+// name or receiver (malformed source) is skipped.
+func collectGoConcreteMethod(node *sitter.Node, source []byte, importPath string, typeMethods map[string][]GoMethodSignature) {
+	nameNode := node.ChildByFieldName("name")
+	receiverNode := node.ChildByFieldName("receiver")
+	if nameNode == nil || receiverNode == nil {
+		return
+	}
+
+	receiverType, pointerRecv := goReceiverTypeAndKind(receiverNode, source)
+	if receiverType == "" {
+		return
+	}
+
+	sig := GoMethodSignature{
+		Name:        nameNode.Content(source),
+		Params:      goParamTypes(node.ChildByFieldName("parameters"), source),
+		Returns:     goResultTypes(node.ChildByFieldName("result"), source),
+		PointerRecv: pointerRecv,
+	}
+	typeFQN := importPath + "." + receiverType
+	typeMethods[typeFQN] = append(typeMethods[typeFQN], sig)
+}
+
+// goReceiverTypeAndKind extracts a method_declaration receiver's type name
+// and whether it's a pointer receiver (mirrors extraction's
+// extractReceiverType, which only needs the type name).
+func goReceiverTypeAndKind(receiverNode *sitter.Node, source []byte) (string, bool) {
+	for i := 0; i < int(receiverNode.NamedChildCount()); i++ {
+		param := receiverNode.NamedChild(i)
+		if param.Type() != "parameter_declaration" {
+			continue
+		}
+		typeNode := param.ChildByFieldName("type")
+		if typeNode == nil {
+			continue
+		}
+		typeName := typeNode.Content(source)
+		if strings.HasPrefix(typeName, "*") {
+			return strings.TrimPrefix(typeName, "*"), true
+		}
+		return typeName, false
+	}
+	return "", false
+}
+
+// collectGoTypeSpecs records every interface type_spec under node (a
+// type_declaration, possibly a parenthesized block of several type_specs) in
+// interfaceMethods. Non-interface type_specs (struct, alias, etc.) are
+// ignored here — BuildGoInterfaceCHA only needs interface requirements and
+// concrete method sets, not every declared type.
+func collectGoTypeSpecs(node *sitter.Node, source []byte, importPath string, interfaceMethods map[string][]GoMethodSignature) {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		spec := node.NamedChild(i)
+		if spec.Type() != "type_spec" {
+			continue
+		}
+		nameNode := spec.ChildByFieldName("name")
+		typeNode := spec.ChildByFieldName("type")
+		if nameNode == nil || typeNode == nil || typeNode.Type() != "interface_type" {
+			continue
+		}
+		ifaceFQN := importPath + "." + nameNode.Content(source)
+		interfaceMethods[ifaceFQN] = goInterfaceMethodSet(typeNode, source)
+	}
+}
+
+// goInterfaceMethodSet collects every method_spec directly inside ifaceNode
+// (an interface_type). Embedded interfaces (type_elem children) aren't
+// expanded into their own method sets here — a conservative limitation that
+// only costs missed implementors for interfaces built by embedding, not
+// false positives.
+func goInterfaceMethodSet(ifaceNode *sitter.Node, source []byte) []GoMethodSignature {
+	var methods []GoMethodSignature
+	for i := 0; i < int(ifaceNode.NamedChildCount()); i++ {
+		spec := ifaceNode.NamedChild(i)
+		if spec.Type() != "method_spec" {
+			continue
+		}
+		nameNode := spec.ChildByFieldName("name")
+		if nameNode == nil {
+			continue
+		}
+		methods = append(methods, GoMethodSignature{
+			Name:    nameNode.Content(source),
+			Params:  goParamTypes(spec.ChildByFieldName("parameters"), source),
+			Returns: goResultTypes(spec.ChildByFieldName("result"), source),
+		})
+	}
+	return methods
+}
+
+// goParamTypes extracts the declared type expression of every
+// parameter_declaration directly inside paramsNode (a parameter_list), in
+// order.
+func goParamTypes(paramsNode *sitter.Node, source []byte) []string {
+	if paramsNode == nil {
+		return nil
+	}
+	var types []string
+	for i := 0; i < int(paramsNode.NamedChildCount()); i++ {
+		param := paramsNode.NamedChild(i)
+		if param.Type() != "parameter_declaration" {
+			continue
+		}
+		if typeNode := param.ChildByFieldName("type"); typeNode != nil {
+			types = append(types, typeNode.Content(source))
+		}
+	}
+	return types
+}
+
+// goResultTypes extracts a function/method signature's result types. The Go
+// grammar represents a single unnamed result as a bare type node and
+// multiple (or named) results as a parameter_list of parameter_declaration
+// nodes — the same shape goFunctionReturns already handles for stdlib
+// signature parsing.
+func goResultTypes(resultNode *sitter.Node, source []byte) []string {
+	if resultNode == nil {
+		return nil
+	}
+	if resultNode.Type() != "parameter_list" {
+		return []string{resultNode.Content(source)}
+	}
+	return goParamTypes(resultNode, source)
+}
@@ -0,0 +1,213 @@
+package resolution
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// Fact is one serializable unit of cross-package type information, modeled
+// on golang.org/x/tools/go/analysis's Facts framework: each Fact knows its
+// own wire format and a Kind identifying which Fact type a decoded blob
+// belongs to, so a FactStore can hold heterogeneous Facts per content hash
+// without a type switch at the storage layer. Facts must reference other
+// packages' types by FQN only — never a Go pointer — so they remain valid
+// across process boundaries (e.g. a cache written by one analysis run and
+// read by another).
+type Fact interface {
+	Encode() ([]byte, error)
+	Decode([]byte) error
+	Kind() string
+}
+
+// Fact kinds recognized by FactStore's callers.
+const (
+	ReturnTypeFactKind    = "return_type"
+	VariableScopeFactKind = "variable_scope"
+)
+
+// ReturnTypeFact records one function's inferred return type (Pass 2a),
+// keyed by FQN so it can be merged straight into a
+// GoTypeInferenceEngine.ReturnTypes map on a cache hit.
+type ReturnTypeFact struct {
+	FunctionFQN string
+	ReturnType  *core.TypeInfo
+}
+
+func (f *ReturnTypeFact) Kind() string             { return ReturnTypeFactKind }
+func (f *ReturnTypeFact) Encode() ([]byte, error)  { return json.Marshal(f) }
+func (f *ReturnTypeFact) Decode(data []byte) error { return json.Unmarshal(data, f) }
+
+// VariableScopeFact records one function's inferred variable bindings
+// (Pass 2b), keyed by FQN so a cache hit can repopulate a GoFunctionScope
+// without re-running the tree-sitter traversal that produced it.
+type VariableScopeFact struct {
+	FunctionFQN string
+	Bindings    []*GoVariableBinding
+}
+
+func (f *VariableScopeFact) Kind() string             { return VariableScopeFactKind }
+func (f *VariableScopeFact) Encode() ([]byte, error)  { return json.Marshal(f) }
+func (f *VariableScopeFact) Decode(data []byte) error { return json.Unmarshal(data, f) }
+
+// factArtifact is the on-disk JSON shape for one content hash. Facts is
+// keyed by "<kind>|<key>" so multiple Facts of the same kind (e.g. one
+// VariableScopeFact per function declared in a file) coexist without
+// clobbering each other; Functions is the set of keys Put has been called
+// with for this hash, letting a cache-hit caller discover which functions
+// this file has Facts for without re-parsing it.
+type factArtifact struct {
+	Facts     map[string]json.RawMessage `json:"facts"`
+	Functions []string                   `json:"functions,omitempty"`
+}
+
+// FactStore persists Facts to per-content-hash JSON artifacts under dir, so
+// a later run against unchanged source can skip re-extraction entirely for
+// the files/functions it already has answers for. Safe for concurrent use.
+type FactStore struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewFactStore returns a FactStore rooted at dir. dir is created lazily on
+// the first Put — a store over a directory that doesn't exist yet (or never
+// receives a Put) is simply always a cache miss.
+func NewFactStore(dir string) *FactStore {
+	return &FactStore{dir: dir}
+}
+
+// ContentHash computes the cache key for one source file: a SHA-256 digest
+// over its contents and its sorted import list, so a file whose text is
+// unchanged but whose resolved imports shifted (e.g. a go.mod upgrade,
+// vendor sync) is still treated as a miss.
+func ContentHash(sourceCode []byte, imports []string) string {
+	sorted := append([]string(nil), imports...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	h.Write(sourceCode)
+	for _, imp := range sorted {
+		h.Write([]byte{0})
+		h.Write([]byte(imp))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *FactStore) artifactPath(hash string) string {
+	return filepath.Join(s.dir, hash+".facts.json")
+}
+
+func (s *FactStore) readArtifact(hash string) (factArtifact, bool) {
+	data, err := os.ReadFile(s.artifactPath(hash))
+	if err != nil {
+		return factArtifact{}, false
+	}
+	var artifact factArtifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return factArtifact{}, false
+	}
+	return artifact, true
+}
+
+// Functions returns the set of function FQNs Put has recorded Facts for
+// under hash, so a cache-hit caller knows which keys to Load without having
+// parsed the file to discover its function names.
+func (s *FactStore) Functions(hash string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	artifact, ok := s.readArtifact(hash)
+	if !ok {
+		return nil
+	}
+	return artifact.Functions
+}
+
+// Load looks up the Fact previously Put under hash and key and decodes it
+// into fact (fact.Kind() identifies which of possibly several Facts at that
+// key to read). Returns false, nil on any cache miss — no artifact for
+// hash, no entry for that kind+key, or a decode error — since all three are
+// equally "nothing usable here" rather than a hard failure the caller must
+// handle specially.
+func (s *FactStore) Load(hash, key string, fact Fact) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	artifact, ok := s.readArtifact(hash)
+	if !ok {
+		return false, nil
+	}
+	raw, ok := artifact.Facts[fact.Kind()+"|"+key]
+	if !ok {
+		return false, nil
+	}
+	if err := fact.Decode(raw); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Put stores fact under hash and key (conventionally the function FQN the
+// fact is about), merging with any Facts already recorded for that hash,
+// and writes the artifact atomically (temp file + rename) so a crash
+// mid-write never leaves a partially-written artifact behind.
+func (s *FactStore) Put(hash, key string, fact Fact) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	artifact, ok := s.readArtifact(hash)
+	if !ok {
+		artifact = factArtifact{}
+	}
+	if artifact.Facts == nil {
+		artifact.Facts = map[string]json.RawMessage{}
+	}
+
+	encoded, err := fact.Encode()
+	if err != nil {
+		return fmt.Errorf("encode %s fact: %w", fact.Kind(), err)
+	}
+	artifact.Facts[fact.Kind()+"|"+key] = encoded
+
+	known := false
+	for _, fn := range artifact.Functions {
+		if fn == key {
+			known = true
+			break
+		}
+	}
+	if !known {
+		artifact.Functions = append(artifact.Functions, key)
+	}
+
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return fmt.Errorf("marshal fact artifact: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create fact store dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".fact-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp fact file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp fact file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp fact file: %w", err)
+	}
+	return os.Rename(tmpPath, s.artifactPath(hash))
+}
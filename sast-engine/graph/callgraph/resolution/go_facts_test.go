@@ -0,0 +1,87 @@
+package resolution
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactStore_PutThenLoadRoundTrips(t *testing.T) {
+	store := NewFactStore(t.TempDir())
+	hash := ContentHash([]byte("package main"), []string{"fmt"})
+
+	require.NoError(t, store.Put(hash, "pkg.F", &ReturnTypeFact{
+		FunctionFQN: "pkg.F",
+		ReturnType:  &core.TypeInfo{TypeFQN: "builtin.string"},
+	}))
+
+	var fact ReturnTypeFact
+	hit, err := store.Load(hash, "pkg.F", &fact)
+	require.NoError(t, err)
+	require.True(t, hit)
+	assert.Equal(t, "pkg.F", fact.FunctionFQN)
+	assert.Equal(t, "builtin.string", fact.ReturnType.TypeFQN)
+}
+
+func TestFactStore_LoadMissIsFalseNotError(t *testing.T) {
+	store := NewFactStore(t.TempDir())
+	var fact ReturnTypeFact
+	hit, err := store.Load("nonexistent-hash", "pkg.F", &fact)
+	require.NoError(t, err)
+	assert.False(t, hit)
+}
+
+func TestFactStore_MultipleFunctionsUnderSameHashDoNotClobber(t *testing.T) {
+	store := NewFactStore(t.TempDir())
+	hash := ContentHash([]byte("package main"), nil)
+
+	require.NoError(t, store.Put(hash, "pkg.F", &VariableScopeFact{
+		FunctionFQN: "pkg.F",
+		Bindings:    []*GoVariableBinding{{VarName: "x", Type: &core.TypeInfo{TypeFQN: "builtin.int"}}},
+	}))
+	require.NoError(t, store.Put(hash, "pkg.G", &VariableScopeFact{
+		FunctionFQN: "pkg.G",
+		Bindings:    []*GoVariableBinding{{VarName: "y", Type: &core.TypeInfo{TypeFQN: "builtin.string"}}},
+	}))
+
+	var fFact, gFact VariableScopeFact
+	hit, err := store.Load(hash, "pkg.F", &fFact)
+	require.NoError(t, err)
+	require.True(t, hit)
+	assert.Equal(t, "x", fFact.Bindings[0].VarName)
+
+	hit, err = store.Load(hash, "pkg.G", &gFact)
+	require.NoError(t, err)
+	require.True(t, hit)
+	assert.Equal(t, "y", gFact.Bindings[0].VarName)
+
+	assert.ElementsMatch(t, []string{"pkg.F", "pkg.G"}, store.Functions(hash))
+}
+
+func TestFactStore_FunctionsEmptyOnMiss(t *testing.T) {
+	store := NewFactStore(t.TempDir())
+	assert.Empty(t, store.Functions("nonexistent-hash"))
+}
+
+func TestContentHash_StableForSameInputs(t *testing.T) {
+	a := ContentHash([]byte("package main"), []string{"fmt", "os"})
+	b := ContentHash([]byte("package main"), []string{"os", "fmt"})
+	assert.Equal(t, a, b, "import order shouldn't affect the hash")
+}
+
+func TestContentHash_ChangesWithImports(t *testing.T) {
+	a := ContentHash([]byte("package main"), []string{"fmt"})
+	b := ContentHash([]byte("package main"), []string{"fmt", "os"})
+	assert.NotEqual(t, a, b)
+}
+
+func TestFactStore_ArtifactPathIsUnderDir(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFactStore(dir)
+	hash := ContentHash([]byte("x"), nil)
+	require.NoError(t, store.Put(hash, "pkg.F", &ReturnTypeFact{FunctionFQN: "pkg.F", ReturnType: &core.TypeInfo{TypeFQN: "builtin.int"}}))
+	assert.FileExists(t, filepath.Join(dir, hash+".facts.json"))
+}
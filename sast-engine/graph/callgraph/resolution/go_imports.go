@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
@@ -14,7 +15,31 @@ import (
 
 // BuildGoModuleRegistry builds a registry mapping directories to Go import paths.
 // It parses go.mod to extract the module path, then walks the directory tree to build
-// bidirectional mappings between directories and import paths.
+// bidirectional mappings between directories and import paths. go.mod's
+// require/replace/exclude directives are also recorded on the registry
+// itself (Requires, Replaces, Excludes), so callers can consult them
+// without re-parsing go.mod, and a local `replace` directive makes
+// GoImportResolver.ClassifyImport treat the replaced import as ImportLocal
+// (see NewGoImportResolver and ResolveToDir). When a vendor/ directory is
+// present, its modules.txt is indexed into VendoredPackages too, so
+// third-party imports built with -mod=vendor classify as ImportVendored
+// with a concrete on-disk directory.
+//
+// When projectRoot also contains a go.work file, every module named by its
+// `use` directives is folded into the returned registry too (see
+// mergeGoWorkspaceIntoRegistry), so a caller that only knows about
+// BuildGoModuleRegistry — not the workspace-specific BuildGoWorkspaceRegistry /
+// NewGoImportResolverForWorkspace APIs — still gets cross-module imports
+// resolved as local.
+//
+// A go.mod nested inside projectRoot (e.g. a `tools/` submodule, or a
+// v2/go.mod semantic-import-versioning subdirectory) is parsed as its own
+// registry and recorded in registry.SubModules (see attachSubModule); its
+// directories are attributed to *its own* ModulePath, not projectRoot's, in
+// the returned registry's DirToImport/ImportToDir. Use ModuleForFile to find
+// which of registry or registry.SubModules actually encloses a given file,
+// and GoImportResolver.ClassifyImportFrom to classify an import the way that
+// enclosing module would.
 //
 // Parameters:
 //   - projectRoot: absolute path to the project root (contains go.mod)
@@ -22,6 +47,31 @@ import (
 // Returns:
 //   - populated GoModuleRegistry or error if go.mod is missing/invalid
 func BuildGoModuleRegistry(projectRoot string) (*core.GoModuleRegistry, error) {
+	registry, err := buildGoModuleRegistryCore(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// If projectRoot is also a go.work workspace root, fold every sibling
+	// module's directory/import mappings in too. This is intentionally not
+	// part of buildGoModuleRegistryCore: workspace members are themselves
+	// built with buildGoModuleRegistryCore (not BuildGoModuleRegistry), since
+	// a `use .` entry referring back to projectRoot itself would otherwise
+	// recurse into this same go.work forever.
+	if _, statErr := os.Stat(filepath.Join(projectRoot, "go.work")); statErr == nil {
+		mergeGoWorkspaceIntoRegistry(registry, projectRoot)
+	}
+
+	return registry, nil
+}
+
+// buildGoModuleRegistryCore does the single-module work BuildGoModuleRegistry
+// has always done — parse go.mod, walk the directory tree, fold local
+// replace directives — without BuildGoModuleRegistry's additional go.work
+// auto-detection. Workspace members are built with this instead of
+// BuildGoModuleRegistry so resolving a workspace never recurses back into
+// the same go.work file.
+func buildGoModuleRegistryCore(projectRoot string) (*core.GoModuleRegistry, error) {
 	registry := core.NewGoModuleRegistry()
 
 	// Step 1: Parse go.mod to get module path and Go version
@@ -38,8 +88,156 @@ func BuildGoModuleRegistry(projectRoot string) (*core.GoModuleRegistry, error) {
 		return nil, err
 	}
 
-	// Step 3: Walk directory tree to build import path mappings
-	err = filepath.Walk(absRoot, func(path string, info os.FileInfo, err error) error {
+	// Step 3: Walk directory tree to build import path mappings. A nested
+	// go.mod is parsed as its own submodule registry and folded into registry
+	// (see attachSubModule) rather than simply skipped, so files under it
+	// still resolve to a correct import path and module.
+	if err := walkGoModuleTree(absRoot, absRoot, modulePath, registry, func(nestedDir string) error {
+		attachSubModule(registry, nestedDir)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to walk directory tree: %w", err)
+	}
+
+	// Step 4: Fold local `replace` directives into the registry so imports of
+	// the replaced module path resolve to the replacement's on-disk directory.
+	replacements, repErr := ParseGoModReplacements(projectRoot)
+	if repErr == nil {
+		applyGoModReplacements(registry, replacements)
+	}
+
+	// Step 5: Record require/replace/exclude directives on the registry
+	// itself, keyed by module path, so callers (and GoImportResolver) can
+	// consult them directly instead of re-parsing go.mod.
+	if requires, reqErr := ParseGoModRequires(projectRoot); reqErr == nil {
+		registry.Requires = make(map[string]string, len(requires))
+		for _, req := range requires {
+			registry.Requires[req.Path] = req.Version
+		}
+	}
+	if repErr == nil {
+		registry.Replaces = make(map[string]core.Replacement, len(replacements))
+		for _, rep := range replacements {
+			registry.Replaces[rep.Old] = core.Replacement{
+				NewPath:    rep.NewPath,
+				NewVersion: rep.NewVersion,
+				NewDir:     rep.NewDir,
+				Local:      rep.Local,
+			}
+		}
+	}
+	if excludes, excErr := ParseGoModExcludes(projectRoot); excErr == nil {
+		registry.Excludes = make(map[string]bool, len(excludes))
+		for _, exc := range excludes {
+			registry.Excludes[exc.Path] = true
+		}
+	}
+
+	// Step 6: Index vendor/modules.txt, when present, so third-party imports
+	// built with -mod=vendor resolve to their on-disk vendor/ directory (see
+	// BuildVendorIndex and GoImportResolver.ClassifyImport/ResolveToDir).
+	// walkGoModuleTree (Step 3) never descends into vendor/ itself — see
+	// shouldSkipGoDirectory — so this is the only place vendored packages are
+	// indexed.
+	if vendored, vendErr := BuildVendorIndex(projectRoot); vendErr == nil {
+		registry.VendoredPackages = vendored
+	}
+
+	return registry, nil
+}
+
+// mergeGoWorkspaceIntoRegistry folds every member of the go.work workspace
+// rooted at workspaceRoot into registry: DirToImport/ImportToDir entries are
+// unioned in, and each member's module path (other than registry's own) is
+// recorded in registry.WorkspaceModulePaths, which NewGoImportResolver
+// consults so ClassifyImport treats any workspace member's import as
+// ImportLocal without the caller having to build a separate
+// WorkspaceRegistry. Errors building the workspace are ignored — a malformed
+// or partially-resolvable go.work degrades to the single-module registry
+// already built, the same best-effort handling BuildGoModuleRegistry already
+// applies to `replace` directives.
+func mergeGoWorkspaceIntoRegistry(registry *core.GoModuleRegistry, workspaceRoot string) {
+	ws, err := BuildGoWorkspaceRegistry(workspaceRoot)
+	if err != nil {
+		return
+	}
+
+	for dir, importPath := range ws.DirToImport {
+		registry.DirToImport[dir] = importPath
+	}
+	for importPath, dir := range ws.ImportToDir {
+		registry.ImportToDir[importPath] = dir
+	}
+	for _, member := range ws.Members {
+		if member.ModulePath == "" || member.ModulePath == registry.ModulePath {
+			continue
+		}
+		registry.WorkspaceModulePaths = append(registry.WorkspaceModulePaths, member.ModulePath)
+	}
+
+	// go.work supports its own `replace` directives (same syntax as go.mod),
+	// which apply across the whole workspace — including the root module
+	// itself, not just the members BuildGoWorkspaceRegistry already applied
+	// them to.
+	if workspaceReplacements, repErr := ParseGoModReplacements(workspaceRoot); repErr == nil {
+		applyGoModReplacements(registry, workspaceReplacements)
+	}
+}
+
+// ModuleRegistrySet groups a project's root module registry together with any
+// nested module registries discovered while walking the tree — e.g. a
+// `v2/go.mod` semantic-import-versioning subdirectory, or an independent tool
+// module rooted at `cmd/foo/go.mod`. Nested registries are keyed by the
+// absolute directory their go.mod lives in.
+type ModuleRegistrySet struct {
+	Root   *core.GoModuleRegistry
+	Nested map[string]*core.GoModuleRegistry
+}
+
+// BuildGoModuleRegistrySet builds the root module registry for projectRoot and
+// recursively builds a separate GoModuleRegistry for every nested go.mod it
+// discovers, so files under a nested module resolve against that module's own
+// import path rather than the parent's.
+func BuildGoModuleRegistrySet(projectRoot string) (*ModuleRegistrySet, error) {
+	root := core.NewGoModuleRegistry()
+
+	modulePath, goVersion, err := parseGoMod(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	root.ModulePath = modulePath
+	root.GoVersion = goVersion
+
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &ModuleRegistrySet{Root: root, Nested: make(map[string]*core.GoModuleRegistry)}
+
+	collectNested := func(nestedDir string) error {
+		nestedReg, nestedErr := BuildGoModuleRegistry(nestedDir)
+		if nestedErr != nil {
+			return nestedErr
+		}
+		set.Nested[nestedDir] = nestedReg
+		return nil
+	}
+
+	if err := walkGoModuleTree(absRoot, absRoot, modulePath, root, collectNested); err != nil {
+		return nil, fmt.Errorf("failed to walk directory tree: %w", err)
+	}
+
+	return set, nil
+}
+
+// walkGoModuleTree walks absRoot and populates registry's DirToImport/ImportToDir
+// mappings for every directory that belongs to modulePath. When it encounters a
+// go.mod in a subdirectory (other than moduleRoot itself) it stops attributing
+// that subtree to registry and, if onNestedModule is non-nil, invokes it with
+// the nested module's directory so the caller can build a separate registry.
+func walkGoModuleTree(absRoot, moduleRoot, modulePath string, registry *core.GoModuleRegistry, onNestedModule func(dir string) error) error {
+	return filepath.Walk(absRoot, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -54,6 +252,20 @@ func BuildGoModuleRegistry(projectRoot string) (*core.GoModuleRegistry, error) {
 			return filepath.SkipDir
 		}
 
+		// A go.mod in a subdirectory (not the module's own root) marks the
+		// boundary of a nested module: everything below it belongs to that
+		// module, not this one.
+		if path != moduleRoot {
+			if _, statErr := os.Stat(filepath.Join(path, "go.mod")); statErr == nil {
+				if onNestedModule != nil {
+					if nestedErr := onNestedModule(path); nestedErr != nil {
+						return nestedErr
+					}
+				}
+				return filepath.SkipDir
+			}
+		}
+
 		// Calculate relative path from root
 		relPath, err := filepath.Rel(absRoot, path)
 		if err != nil {
@@ -78,12 +290,180 @@ func BuildGoModuleRegistry(projectRoot string) (*core.GoModuleRegistry, error) {
 
 		return nil
 	})
+}
 
+// attachSubModule builds a full registry for the nested module rooted at
+// nestedDir and folds it into parent: nestedDir's own DirToImport/ImportToDir
+// entries are unioned into parent's (the same "union the flat maps" approach
+// mergeGoWorkspaceIntoRegistry uses for go.work members), so a caller that
+// only ever looks at parent's flat maps still resolves a file under the
+// submodule to its own import path rather than getting nothing back. The
+// nested registry is also kept in parent.SubModules so ModuleForFile and
+// GoImportResolver.ClassifyImportFrom can tell the two modules apart when
+// that distinction matters (e.g. deciding whether an import is local to the
+// *submodule*, not just resolving a path).
+//
+// A nested go.mod that fails to parse is skipped rather than failing the
+// whole walk — the parent registry remains the thing most callers rely on,
+// so this degrades the same way a malformed go.work or replace directive
+// already does elsewhere in this file.
+func attachSubModule(parent *core.GoModuleRegistry, nestedDir string) {
+	nested, err := buildGoModuleRegistryCore(nestedDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to walk directory tree: %w", err)
+		return
 	}
 
-	return registry, nil
+	parent.SubModules = append(parent.SubModules, nested)
+	for dir, importPath := range nested.DirToImport {
+		parent.DirToImport[dir] = importPath
+	}
+	for importPath, dir := range nested.ImportToDir {
+		parent.ImportToDir[importPath] = dir
+	}
+}
+
+// ModuleForFile returns whichever of registry and its (possibly nested)
+// SubModules most tightly encloses filePath on disk: the module whose own
+// root directory is the longest matching prefix of filePath's directory.
+// Falls back to registry itself when no submodule's root directory contains
+// filePath, and to nil when registry itself is nil.
+func ModuleForFile(registry *core.GoModuleRegistry, filePath string) *core.GoModuleRegistry {
+	if registry == nil {
+		return nil
+	}
+
+	dir := filepath.Dir(filePath)
+	best := registry
+	bestLen := -1
+
+	var walk func(reg *core.GoModuleRegistry)
+	walk = func(reg *core.GoModuleRegistry) {
+		if root := reg.ImportToDir[reg.ModulePath]; root != "" && dirContains(root, dir) && len(root) > bestLen {
+			best = reg
+			bestLen = len(root)
+		}
+		for _, sub := range reg.SubModules {
+			walk(sub)
+		}
+	}
+	walk(registry)
+
+	return best
+}
+
+// dirContains reports whether dir is root itself or a descendant of root.
+func dirContains(root, dir string) bool {
+	if dir == root {
+		return true
+	}
+	return strings.HasPrefix(dir, root+string(filepath.Separator))
+}
+
+// WorkspaceRegistry unions the module registries of every member of a
+// go.work workspace. DirToImport/ImportToDir are merged across all members so
+// callers can resolve a directory or import path without knowing which member
+// module it belongs to.
+type WorkspaceRegistry struct {
+	Members     []*core.GoModuleRegistry
+	DirToImport map[string]string
+	ImportToDir map[string]string
+}
+
+// goWorkUseRegex matches a single `use ./path` line inside go.work, with or
+// without surrounding parens (the block form strips parens before matching).
+var goWorkUseRegex = regexp.MustCompile(`^use\s+(\S+)$`)
+
+// parseGoWorkUses reads go.work in workspaceRoot and returns the absolute
+// directory of every `use` entry, in both single-line and block
+// (`use (\n ./a\n ./b\n)`) form.
+func parseGoWorkUses(workspaceRoot string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(workspaceRoot, "go.work"))
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	inBlock := false
+	for _, rawLine := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch {
+		case line == "use (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			line = "use " + line
+		case !strings.HasPrefix(line, "use "):
+			continue
+		}
+
+		match := goWorkUseRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		dirs = append(dirs, filepath.Clean(filepath.Join(workspaceRoot, match[1])))
+	}
+	return dirs, nil
+}
+
+// BuildGoWorkspaceRegistry parses go.work in workspaceRoot, builds a
+// GoModuleRegistry for each `use` entry, and unions their directory/import
+// mappings into a single WorkspaceRegistry. Workspace-level `replace`
+// directives (go.work supports the same syntax as go.mod) are applied across
+// every member so a replacement declared once in go.work affects all of them.
+func BuildGoWorkspaceRegistry(workspaceRoot string) (*WorkspaceRegistry, error) {
+	useDirs, err := parseGoWorkUses(workspaceRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	ws := &WorkspaceRegistry{
+		DirToImport: make(map[string]string),
+		ImportToDir: make(map[string]string),
+	}
+
+	workspaceReplacements, _ := ParseGoModReplacements(workspaceRoot)
+
+	for _, dir := range useDirs {
+		member, memberErr := buildGoModuleRegistryCore(dir)
+		if memberErr != nil {
+			return nil, fmt.Errorf("failed to build module registry for workspace member %s: %w", dir, memberErr)
+		}
+		applyGoModReplacements(member, workspaceReplacements)
+
+		ws.Members = append(ws.Members, member)
+		for d, imp := range member.DirToImport {
+			ws.DirToImport[d] = imp
+		}
+		for imp, d := range member.ImportToDir {
+			ws.ImportToDir[imp] = d
+		}
+	}
+
+	return ws, nil
+}
+
+// NewGoImportResolverForWorkspace creates a GoImportResolver that treats an
+// import as ImportLocal when it belongs to any member module of the
+// workspace, in addition to whatever rootRegistry (e.g. the module the
+// current file lives in) already classifies as local.
+func NewGoImportResolverForWorkspace(rootRegistry *core.GoModuleRegistry, ws *WorkspaceRegistry) *GoImportResolver {
+	r := NewGoImportResolver(rootRegistry)
+	if ws == nil {
+		return r
+	}
+	for _, member := range ws.Members {
+		if member.ModulePath != "" {
+			r.localModulePaths = append(r.localModulePaths, member.ModulePath)
+		}
+	}
+	return r
 }
 
 // ExtractGoImports extracts import statements from a Go source file.
@@ -93,13 +473,25 @@ func BuildGoModuleRegistry(projectRoot string) (*core.GoModuleRegistry, error) {
 // Parameters:
 //   - filePath: absolute path to the Go source file
 //   - sourceCode: the file's source code as bytes
-//   - registry: the Go module registry (currently unused but kept for consistency)
+//   - registry: the Go module registry; if it carries a BuildContext, imports
+//     gated by unsatisfied //go:build / +build constraints (or an OS/arch
+//     filename suffix) are marked Constrained rather than dropped, so callers
+//     can still see what a file would import under a different context. With
+//     no BuildContext set, DefaultGoBuildContext() (the running toolchain's
+//     own GOOS/GOARCH) is used, matching this function's behavior before
+//     build-constraint evaluation existed.
 //
 // Returns:
 //   - GoImportMap containing all imports, or error if parsing fails
 func ExtractGoImports(filePath string, sourceCode []byte, registry *core.GoModuleRegistry) (*core.GoImportMap, error) {
 	importMap := core.NewGoImportMap(filePath)
 
+	buildCtx := DefaultGoBuildContext()
+	if registry != nil && registry.BuildContext != nil {
+		buildCtx = *registry.BuildContext
+	}
+	constrained := !EvaluateGoBuildConstraints(filePath, sourceCode, buildCtx)
+
 	// Parse with tree-sitter
 	parser := sitter.NewParser()
 	parser.SetLanguage(golang.GetLanguage())
@@ -132,6 +524,18 @@ func ExtractGoImports(filePath string, sourceCode []byte, registry *core.GoModul
 	// Step 2: Traverse AST to find imports
 	traverseForGoImports(rootNode, sourceCode, importMap)
 
+	// Step 3: Annotate every import found with whether the file itself is
+	// excluded by build constraints under buildCtx. Constraints are file-wide
+	// in Go (there's no such thing as a per-import-line tag), so every alias
+	// gets the same value; the map shape still lets a caller filter out just
+	// the imports belonging to constrained files.
+	if constrained && len(importMap.Imports) > 0 {
+		importMap.Constrained = make(map[string]bool, len(importMap.Imports))
+		for alias := range importMap.Imports {
+			importMap.Constrained[alias] = true
+		}
+	}
+
 	return importMap, nil
 }
 
@@ -264,6 +668,270 @@ func parseGoMod(projectRoot string) (modulePath string, goVersion string, err er
 	return modulePath, goVersion, nil
 }
 
+// GoModRequire describes a single module listed in go.mod's `require` block
+// (or a single-line `require module version` directive).
+type GoModRequire struct {
+	Path    string
+	Version string
+}
+
+// requireDirectiveRegex matches a single-line `require module version`
+// directive, with or without surrounding parens (the block form strips
+// parens before matching). The optional trailing "// indirect" comment is
+// discarded.
+var requireDirectiveRegex = regexp.MustCompile(`^require\s+(\S+)\s+(\S+)$`)
+
+// ParseGoModRequires reads go.mod in projectRoot and returns every module
+// listed in its `require` directives, in both single-line and block
+// (`require (\n mod version\n)`) form. Returns an empty slice, not an error,
+// when go.mod has no require directives.
+func ParseGoModRequires(projectRoot string) ([]GoModRequire, error) {
+	content, err := os.ReadFile(filepath.Join(projectRoot, "go.mod"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("go.mod not found in %s", projectRoot)
+		}
+		return nil, err
+	}
+
+	var requires []GoModRequire
+	inBlock := false
+	for _, rawLine := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case line == "require (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			line = "require " + line
+		case !strings.HasPrefix(line, "require "):
+			continue
+		}
+
+		match := requireDirectiveRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		requires = append(requires, GoModRequire{Path: match[1], Version: match[2]})
+	}
+
+	return requires, nil
+}
+
+// ParseGoSumVersions reads go.sum in projectRoot and returns the resolved
+// version for every module it records, keyed by module path. go.sum is the
+// authoritative source for which version of a dependency was actually
+// selected (go.mod's `require` version can be a floating minimum), so
+// callers that need the on-disk directory a dependency lives under — e.g.
+// resolving a GOMODCACHE path — should prefer this over GoModRequire.Version.
+//
+// Each module appears twice in go.sum: once for its source zip and once for
+// its go.mod file ("module version/go.mod hash"); only the former is kept.
+// Returns an empty map, not an error, when go.sum does not exist.
+func ParseGoSumVersions(projectRoot string) (map[string]string, error) {
+	content, err := os.ReadFile(filepath.Join(projectRoot, "go.sum"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	versions := make(map[string]string)
+	for _, rawLine := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(rawLine)
+		if len(fields) != 3 {
+			continue
+		}
+		modulePath, version := fields[0], fields[1]
+		if strings.HasSuffix(version, "/go.mod") {
+			continue
+		}
+		versions[modulePath] = version
+	}
+
+	return versions, nil
+}
+
+// GoModReplace describes a single `replace` directive parsed from go.mod.
+//
+// Two shapes are supported:
+//
+//	replace github.com/foo/bar => ../local/bar        // Local: true,  NewDir set
+//	replace github.com/foo/bar => github.com/baz v1.2.3 // Local: false, NewPath/NewVersion set
+type GoModReplace struct {
+	Old        string // module path being replaced
+	NewPath    string // replacement module path (module→module replacements only)
+	NewVersion string // replacement version (module→module replacements only)
+	NewDir     string // absolute directory of the replacement (local replacements only)
+	Local      bool   // true when the replacement target is a local filesystem path
+}
+
+// replaceDirectiveRegex matches a single-line `replace old[ version] => new[ version]`
+// directive, with or without surrounding parens (the block form strips parens
+// before matching).
+var replaceDirectiveRegex = regexp.MustCompile(`^replace\s+(\S+)(?:\s+(\S+))?\s*=>\s*(\S+)(?:\s+(\S+))?$`)
+
+// ParseGoModReplacements reads go.mod in projectRoot and returns every `replace`
+// directive it finds, in both single-line (`replace old => new`) and block
+// (`replace (\n old => new\n)`) form. Local replacement targets (those starting
+// with "." or an absolute path) have NewDir resolved relative to projectRoot.
+// Returns an empty slice, not an error, when go.mod has no replace directives.
+func ParseGoModReplacements(projectRoot string) ([]GoModReplace, error) {
+	goModPath := filepath.Join(projectRoot, "go.mod")
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("go.mod not found in %s", projectRoot)
+		}
+		return nil, err
+	}
+
+	var replacements []GoModReplace
+	inBlock := false
+	for _, rawLine := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch {
+		case line == "replace (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			line = "replace " + line
+		case !strings.HasPrefix(line, "replace "):
+			continue
+		}
+
+		match := replaceDirectiveRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		rep := GoModReplace{Old: match[1], NewPath: match[3], NewVersion: match[4]}
+		if strings.HasPrefix(rep.NewPath, ".") || filepath.IsAbs(rep.NewPath) {
+			rep.Local = true
+			rep.NewDir = filepath.Clean(filepath.Join(projectRoot, rep.NewPath))
+			rep.NewVersion = ""
+		}
+		replacements = append(replacements, rep)
+	}
+
+	return replacements, nil
+}
+
+// applyGoModReplacements folds each local `replace` directive's target
+// directory into registry under the replaced module's own import path, so
+// e.g. `replace github.com/foo/bar => ../bar` makes imports of
+// "github.com/foo/bar/pkg" resolve to "../bar/pkg" on disk. Module→module
+// replacements carry no local source and are left for ClassifyImport to
+// recognise via GoImportResolver.WithReplacements.
+func applyGoModReplacements(registry *core.GoModuleRegistry, replacements []GoModReplace) {
+	for _, rep := range replacements {
+		if !rep.Local {
+			continue
+		}
+		if _, err := os.Stat(rep.NewDir); err != nil {
+			continue
+		}
+		_ = walkGoModuleTree(rep.NewDir, rep.NewDir, rep.Old, registry, nil)
+	}
+}
+
+// GoModExclude describes a single `exclude` directive parsed from go.mod: a
+// specific module version that must be removed from consideration when
+// selecting which version of a dependency to resolve against (e.g. a known-
+// broken release the author has excluded).
+type GoModExclude struct {
+	Path    string
+	Version string
+}
+
+// excludeDirectiveRegex matches a single-line `exclude module version`
+// directive, with or without surrounding parens (the block form strips
+// parens before matching) — the same two-token shape as a require directive.
+var excludeDirectiveRegex = regexp.MustCompile(`^exclude\s+(\S+)\s+(\S+)$`)
+
+// ParseGoModExcludes reads go.mod in projectRoot and returns every `exclude`
+// directive it finds, in both single-line and block
+// (`exclude (\n mod version\n)`) form. Returns an empty slice, not an error,
+// when go.mod has no exclude directives.
+func ParseGoModExcludes(projectRoot string) ([]GoModExclude, error) {
+	content, err := os.ReadFile(filepath.Join(projectRoot, "go.mod"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("go.mod not found in %s", projectRoot)
+		}
+		return nil, err
+	}
+
+	var excludes []GoModExclude
+	inBlock := false
+	for _, rawLine := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch {
+		case line == "exclude (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			line = "exclude " + line
+		case !strings.HasPrefix(line, "exclude "):
+			continue
+		}
+
+		match := excludeDirectiveRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		excludes = append(excludes, GoModExclude{Path: match[1], Version: match[2]})
+	}
+
+	return excludes, nil
+}
+
+// FilterExcludedRequires returns requires with every entry matching one of
+// excludes (same module path and version) removed. Used ahead of any
+// version-selection logic that consumes ParseGoModRequires' output (e.g.
+// resolving a GOMODCACHE directory) so an excluded version is never chosen.
+func FilterExcludedRequires(requires []GoModRequire, excludes []GoModExclude) []GoModRequire {
+	if len(excludes) == 0 {
+		return requires
+	}
+	excluded := make(map[GoModRequire]bool, len(excludes))
+	for _, ex := range excludes {
+		excluded[GoModRequire{Path: ex.Path, Version: ex.Version}] = true
+	}
+
+	filtered := make([]GoModRequire, 0, len(requires))
+	for _, req := range requires {
+		if !excluded[req] {
+			filtered = append(filtered, req)
+		}
+	}
+	return filtered
+}
+
 // ============================================================================
 // GoImportResolver — dynamic stdlib import classification
 // ============================================================================
@@ -276,8 +944,52 @@ const (
 	ImportStdlib                // Go standard library (e.g., "fmt", "net/http")
 	ImportThirdParty            // External module (e.g., "github.com/gorilla/mux")
 	ImportLocal                 // Same module (e.g., "github.com/myapp/handlers" or "./utils")
+	ImportVendored              // Third-party module vendored under vendor/ (source available on disk)
 )
 
+// ResolvedImport is the result of classifying an import path: its kind, plus
+// the on-disk directory to read its source from when one is known (currently
+// populated for ImportVendored; ImportLocal callers should use the registry's
+// ImportToDir instead).
+type ResolvedImport struct {
+	Kind ImportType
+	Dir  string // absolute directory containing the package's source, if known
+}
+
+// BuildVendorIndex walks vendor/modules.txt under projectRoot and returns a
+// map from each vendored import path to its absolute directory under
+// vendor/. Returns an empty map, not an error, when there is no vendor
+// directory or modules.txt (vendoring is optional).
+//
+// modules.txt format (as written by `go mod vendor`):
+//
+//	# github.com/gorilla/mux v1.8.0
+//	## explicit
+//	github.com/gorilla/mux
+func BuildVendorIndex(projectRoot string) (map[string]string, error) {
+	index := make(map[string]string)
+
+	vendorDir := filepath.Join(projectRoot, "vendor")
+	content, err := os.ReadFile(filepath.Join(vendorDir, "modules.txt"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return nil, err
+	}
+
+	for _, rawLine := range strings.Split(string(content), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "##") || strings.HasPrefix(line, "# ") {
+			continue
+		}
+		// A bare (non-comment) line is a vendored package import path.
+		index[line] = filepath.Join(vendorDir, filepath.FromSlash(line))
+	}
+
+	return index, nil
+}
+
 // GoImportResolver classifies Go import paths as stdlib, third-party, or local.
 // It uses the registry's StdlibLoader for dynamic, version-aware stdlib detection,
 // falling back to a heuristic (no domain in path) when the loader is unavailable.
@@ -289,12 +1001,116 @@ const (
 //	kind := resolver.ClassifyImport("github.com/gorilla/mux")
 type GoImportResolver struct {
 	registry *core.GoModuleRegistry
+
+	// localModulePaths holds module paths that must classify as ImportLocal
+	// even though they don't share registry.ModulePath as a prefix — nested
+	// registries (populated via NewGoImportResolverForSet) and locally
+	// replaced modules (populated via WithReplacements) both add here.
+	localModulePaths []string
+
+	// vendorIndex maps a vendored import path to its absolute directory under
+	// vendor/ (populated via WithVendorIndex).
+	vendorIndex map[string]string
+
+	// moduleReplacements holds module→module `replace` directives (Local ==
+	// false), consulted by RewriteReplacedImport to rewrite a call target's
+	// import path to the replacement's module path before FQN construction.
+	moduleReplacements []GoModReplace
 }
 
 // NewGoImportResolver creates a GoImportResolver backed by the given module registry.
 // registry may be nil; in that case all classification falls back to the heuristic.
+//
+// When registry was built by BuildGoModuleRegistry against a go.work
+// workspace root, registry.WorkspaceModulePaths is seeded into
+// localModulePaths too, so ClassifyImport treats every sibling workspace
+// module as local without the caller needing NewGoImportResolverForWorkspace.
+// Likewise, every local `replace` directive recorded in registry.Replaces is
+// seeded in, so a monorepo stitched together with `replace foo => ../foo`
+// lines is classified as local without the caller needing WithReplacements.
+// registry.VendoredPackages (populated from vendor/modules.txt, see
+// BuildVendorIndex) is seeded into vendorIndex the same way, so a project
+// built with -mod=vendor gets ImportVendored classification without the
+// caller needing WithVendorIndex.
 func NewGoImportResolver(registry *core.GoModuleRegistry) *GoImportResolver {
-	return &GoImportResolver{registry: registry}
+	r := &GoImportResolver{registry: registry}
+	if registry != nil {
+		r.localModulePaths = append(r.localModulePaths, registry.WorkspaceModulePaths...)
+		for old, rep := range registry.Replaces {
+			if rep.Local {
+				r.localModulePaths = append(r.localModulePaths, old)
+			}
+		}
+		if len(registry.VendoredPackages) > 0 {
+			r.vendorIndex = registry.VendoredPackages
+		}
+	}
+	return r
+}
+
+// NewGoImportResolverForSet creates a GoImportResolver backed by a ModuleRegistrySet's
+// root registry, additionally recognising imports belonging to any nested module
+// (e.g. a v2/go.mod subdirectory) as ImportLocal.
+func NewGoImportResolverForSet(set *ModuleRegistrySet) *GoImportResolver {
+	if set == nil {
+		return NewGoImportResolver(nil)
+	}
+	r := &GoImportResolver{registry: set.Root}
+	for _, nested := range set.Nested {
+		if nested.ModulePath != "" {
+			r.localModulePaths = append(r.localModulePaths, nested.ModulePath)
+		}
+	}
+	return r
+}
+
+// WithReplacements makes r additionally classify imports of locally-replaced
+// modules (see ParseGoModReplacements) as ImportLocal, keyed by the replace
+// directive's "old" module path rather than the replacement target's own
+// module path. Returns r for chaining.
+func (r *GoImportResolver) WithReplacements(replacements []GoModReplace) *GoImportResolver {
+	for _, rep := range replacements {
+		if rep.Old == "" {
+			continue
+		}
+		if rep.Local {
+			r.localModulePaths = append(r.localModulePaths, rep.Old)
+			continue
+		}
+		r.moduleReplacements = append(r.moduleReplacements, rep)
+	}
+	return r
+}
+
+// RewriteReplacedImport rewrites importPath to the FQN prefix its nearest
+// matching module→module `replace` directive points at (e.g. `replace
+// github.com/foo/bar => github.com/baz v1.2.3` rewrites an import of
+// "github.com/foo/bar/pkg" to "github.com/baz/pkg"), so a call-graph edge
+// targeting code under the replaced module resolves against the replacement
+// the build will actually use instead of the original, unresolvable path.
+// Local replacements are not rewritten here — ClassifyImport already
+// recognises those as ImportLocal via WithReplacements, and the caller
+// should resolve against the registry's DirToImport/ImportToDir instead.
+//
+// Returns importPath unchanged when no replacement applies.
+func (r *GoImportResolver) RewriteReplacedImport(importPath string) string {
+	for _, rep := range r.moduleReplacements {
+		if importPath == rep.Old {
+			return rep.NewPath
+		}
+		if strings.HasPrefix(importPath, rep.Old+"/") {
+			return rep.NewPath + strings.TrimPrefix(importPath, rep.Old)
+		}
+	}
+	return importPath
+}
+
+// WithVendorIndex makes r resolve third-party imports that are vendored under
+// vendor/ as ImportVendored, carrying the on-disk directory from index (see
+// BuildVendorIndex). Returns r for chaining.
+func (r *GoImportResolver) WithVendorIndex(index map[string]string) *GoImportResolver {
+	r.vendorIndex = index
+	return r
 }
 
 // isStdlibImport reports whether importPath belongs to the Go standard library.
@@ -338,9 +1154,92 @@ func (r *GoImportResolver) ClassifyImport(importPath string) ImportType {
 		strings.HasPrefix(importPath, r.registry.ModulePath) {
 		return ImportLocal
 	}
+	// Imports that share a nested or locally-replaced module's path (e.g. a
+	// v2/go.mod semantic-import-versioning subdirectory, or the "old" side
+	// of a `replace foo => ../local/foo` directive) are also local.
+	for _, localModulePath := range r.localModulePaths {
+		if localModulePath != "" && strings.HasPrefix(importPath, localModulePath) {
+			return ImportLocal
+		}
+	}
+	if _, ok := r.vendorIndex[importPath]; ok {
+		return ImportVendored
+	}
 	return ImportThirdParty
 }
 
+// ClassifyImportFrom is like ClassifyImport, but evaluates importPath's
+// "shares this module's path" rule against whichever module most tightly
+// encloses importerFile on disk (see ModuleForFile) rather than always
+// against r.registry's own module. This matters for a file under a nested
+// submodule (e.g. tools/go.mod): without it, that file's own intra-module
+// imports would share the *submodule's* path, not r.registry's, and would
+// wrongly classify as ImportThirdParty.
+//
+// Every other rule (stdlib, relative imports, workspace/replace-local module
+// paths, vendor) is unchanged from ClassifyImport — only the "current
+// module" comparison is re-pointed at the enclosing module.
+func (r *GoImportResolver) ClassifyImportFrom(importPath, importerFile string) ImportType {
+	enclosing := ModuleForFile(r.registry, importerFile)
+	if enclosing == nil || enclosing == r.registry {
+		return r.ClassifyImport(importPath)
+	}
+
+	if r.isStdlibImport(importPath) {
+		return ImportStdlib
+	}
+	if strings.HasPrefix(importPath, ".") {
+		return ImportLocal
+	}
+	if enclosing.ModulePath != "" && strings.HasPrefix(importPath, enclosing.ModulePath) {
+		return ImportLocal
+	}
+	return r.ClassifyImport(importPath)
+}
+
+// Resolve classifies importPath like ClassifyImport but also returns the
+// on-disk directory to read its source from, when one is known. Only
+// ImportVendored currently carries a Dir; other kinds return Dir == "".
+func (r *GoImportResolver) Resolve(importPath string) ResolvedImport {
+	kind := r.ClassifyImport(importPath)
+	resolved := ResolvedImport{Kind: kind}
+	if kind == ImportVendored {
+		resolved.Dir = r.vendorIndex[importPath]
+	}
+	return resolved
+}
+
+// ResolveToDir returns the on-disk directory importPath resolves to, when
+// known. It first consults the vendor index (see WithVendorIndex), then the
+// registry's own DirToImport/ImportToDir mapping (covers the current module's own packages, workspace members, and
+// any local replace target already walked by BuildGoModuleRegistry), then
+// falls back to computing the path directly from a matching local `replace`
+// directive — needed when the replacement directory didn't exist on disk at
+// build time (so walkGoModuleTree found nothing to index) but does now.
+func (r *GoImportResolver) ResolveToDir(importPath string) (string, bool) {
+	if dir, ok := r.vendorIndex[importPath]; ok {
+		return dir, true
+	}
+	if r.registry == nil {
+		return "", false
+	}
+	if dir, ok := r.registry.ImportToDir[importPath]; ok {
+		return dir, true
+	}
+	for old, rep := range r.registry.Replaces {
+		if !rep.Local {
+			continue
+		}
+		if importPath == old {
+			return rep.NewDir, true
+		}
+		if strings.HasPrefix(importPath, old+"/") {
+			return filepath.Join(rep.NewDir, strings.TrimPrefix(importPath, old+"/")), true
+		}
+	}
+	return "", false
+}
+
 // ResolveImports classifies each import path in the given slice.
 func (r *GoImportResolver) ResolveImports(imports []string) map[string]ImportType {
 	result := make(map[string]ImportType, len(imports))
@@ -350,6 +1249,16 @@ func (r *GoImportResolver) ResolveImports(imports []string) map[string]ImportTyp
 	return result
 }
 
+// ResolveImportsDetailed classifies each import path and, for vendored
+// third-party packages, includes the directory to read their source from.
+func (r *GoImportResolver) ResolveImportsDetailed(imports []string) map[string]ResolvedImport {
+	result := make(map[string]ResolvedImport, len(imports))
+	for _, importPath := range imports {
+		result[importPath] = r.Resolve(importPath)
+	}
+	return result
+}
+
 // shouldSkipGoDirectory returns true if the directory should be skipped during traversal.
 func shouldSkipGoDirectory(dirName string) bool {
 	skipDirs := map[string]bool{
@@ -2,6 +2,8 @@ package resolution
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -10,6 +12,15 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// writeTempFile writes content to a file at relPath inside dir, creating
+// parent directories as needed.
+func writeTempFile(t *testing.T, dir, relPath, content string) {
+	t.Helper()
+	full := filepath.Join(dir, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+	require.NoError(t, os.WriteFile(full, []byte(content), 0o600))
+}
+
 var errMockResolutionNotFound = errors.New("not found in mock")
 
 func TestBuildGoModuleRegistry(t *testing.T) {
@@ -25,11 +36,11 @@ func TestBuildGoModuleRegistry(t *testing.T) {
 			projectRoot: "../../../test-fixtures/golang/module_project",
 			wantModule:  "github.com/example/testapp",
 			wantMappings: map[string]string{
-				".":                    "github.com/example/testapp",
-				"handlers":             "github.com/example/testapp/handlers",
-				"models":               "github.com/example/testapp/models",
-				"utils":                "github.com/example/testapp/utils",
-				"utils/validation":     "github.com/example/testapp/utils/validation",
+				".":                "github.com/example/testapp",
+				"handlers":         "github.com/example/testapp/handlers",
+				"models":           "github.com/example/testapp/models",
+				"utils":            "github.com/example/testapp/utils",
+				"utils/validation": "github.com/example/testapp/utils/validation",
 			},
 			wantErr: false,
 		},
@@ -79,8 +90,704 @@ func TestBuildGoModuleRegistry(t *testing.T) {
 				assert.True(t, ok, "Expected reverse mapping for %s", expectedImport)
 			}
 
-			})
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// BuildGoModuleRegistrySet — nested modules / v2+ semantic-import subdirectories
+// -----------------------------------------------------------------------------
+
+func TestBuildGoModuleRegistrySet_NestedV2Module(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "handlers"), 0o755))
+	writeTempFile(t, dir, "handlers/handler.go", "package handlers\n")
+
+	v2Dir := filepath.Join(dir, "v2")
+	require.NoError(t, os.MkdirAll(v2Dir, 0o755))
+	writeTempFile(t, v2Dir, "go.mod", "module github.com/example/testapp/v2\n\ngo 1.21\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(v2Dir, "handlers"), 0o755))
+	writeTempFile(t, v2Dir, "handlers/handler.go", "package handlers\n")
+
+	set, err := BuildGoModuleRegistrySet(dir)
+	require.NoError(t, err)
+
+	// Root registry must not attribute anything under v2/ to the parent module.
+	assert.Equal(t, "github.com/example/testapp", set.Root.ModulePath)
+	for path := range set.Root.DirToImport {
+		assert.NotContains(t, path, string(filepath.Separator)+"v2")
+	}
+	if imp, ok := set.Root.DirToImport[filepath.Join(dir, "handlers")]; assert.True(t, ok) {
+		assert.Equal(t, "github.com/example/testapp/handlers", imp)
+	}
+
+	// The nested v2 module must have its own registry rooted at v2/.
+	nested, ok := set.Nested[v2Dir]
+	require.True(t, ok, "expected a nested registry rooted at %s", v2Dir)
+	assert.Equal(t, "github.com/example/testapp/v2", nested.ModulePath)
+	imp, ok := nested.DirToImport[filepath.Join(v2Dir, "handlers")]
+	require.True(t, ok)
+	assert.Equal(t, "github.com/example/testapp/v2/handlers", imp)
+}
+
+func TestBuildGoModuleRegistry_AttributesNestedModuleSubtreeToSubModule(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n")
+
+	toolDir := filepath.Join(dir, "cmd", "foo")
+	require.NoError(t, os.MkdirAll(filepath.Join(toolDir, "handlers"), 0o755))
+	writeTempFile(t, toolDir, "go.mod", "module github.com/example/foo-tool\n\ngo 1.21\n")
+	writeTempFile(t, toolDir, "handlers/handler.go", "package handlers\n")
+
+	registry, err := BuildGoModuleRegistry(dir)
+	require.NoError(t, err)
+
+	// The nested directory is attributed to the submodule's own ModulePath,
+	// not silently dropped and not attributed to the parent's.
+	handlersDir := filepath.Join(toolDir, "handlers")
+	imp, ok := registry.DirToImport[handlersDir]
+	require.True(t, ok, "nested module directory %s should be attributed to its submodule", handlersDir)
+	assert.Equal(t, "github.com/example/foo-tool/handlers", imp)
+
+	require.Len(t, registry.SubModules, 1)
+	assert.Equal(t, "github.com/example/foo-tool", registry.SubModules[0].ModulePath)
+}
+
+func TestModuleForFile(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n")
+	toolDir := filepath.Join(dir, "cmd", "foo")
+	require.NoError(t, os.MkdirAll(toolDir, 0o755))
+	writeTempFile(t, toolDir, "go.mod", "module github.com/example/foo-tool\n\ngo 1.21\n")
+
+	registry, err := BuildGoModuleRegistry(dir)
+	require.NoError(t, err)
+
+	rootFile := filepath.Join(dir, "main.go")
+	enclosing := ModuleForFile(registry, rootFile)
+	require.NotNil(t, enclosing)
+	assert.Equal(t, "github.com/example/testapp", enclosing.ModulePath)
+
+	nestedFile := filepath.Join(toolDir, "foo.go")
+	enclosing = ModuleForFile(registry, nestedFile)
+	require.NotNil(t, enclosing)
+	assert.Equal(t, "github.com/example/foo-tool", enclosing.ModulePath)
+}
+
+func TestModuleForFile_NilRegistry(t *testing.T) {
+	assert.Nil(t, ModuleForFile(nil, "/tmp/main.go"))
+}
+
+func TestGoImportResolver_ClassifyImportFrom_NestedModuleImportIsLocal(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n")
+	toolDir := filepath.Join(dir, "cmd", "foo")
+	require.NoError(t, os.MkdirAll(toolDir, 0o755))
+	writeTempFile(t, toolDir, "go.mod", "module github.com/example/foo-tool\n\ngo 1.21\n")
+
+	registry, err := BuildGoModuleRegistry(dir)
+	require.NoError(t, err)
+	r := NewGoImportResolver(registry)
+
+	nestedFile := filepath.Join(toolDir, "foo.go")
+	assert.Equal(t, ImportLocal, r.ClassifyImportFrom("github.com/example/foo-tool/internal", nestedFile))
+	assert.Equal(t, ImportThirdParty, r.ClassifyImport("github.com/example/foo-tool/internal"),
+		"without the importer's file, the submodule's path isn't recognised as local")
+}
+
+func TestGoImportResolver_NewGoImportResolverForSet_NestedModuleIsLocal(t *testing.T) {
+	set := &ModuleRegistrySet{
+		Root: &core.GoModuleRegistry{ModulePath: "github.com/example/testapp"},
+		Nested: map[string]*core.GoModuleRegistry{
+			"/proj/v2": {ModulePath: "github.com/example/testapp/v2"},
+		},
+	}
+	r := NewGoImportResolverForSet(set)
+
+	assert.Equal(t, ImportLocal, r.ClassifyImport("github.com/example/testapp/handlers"))
+	assert.Equal(t, ImportLocal, r.ClassifyImport("github.com/example/testapp/v2/handlers"))
+	assert.Equal(t, ImportThirdParty, r.ClassifyImport("github.com/example/other/pkg"))
+}
+
+func TestGoImportResolver_NewGoImportResolverForSet_NilSet(t *testing.T) {
+	r := NewGoImportResolverForSet(nil)
+	assert.Equal(t, ImportThirdParty, r.ClassifyImport("github.com/gorilla/mux"))
+}
+
+// -----------------------------------------------------------------------------
+// ParseGoModRequires
+// -----------------------------------------------------------------------------
+
+func TestParseGoModRequires_SingleLine(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n\nrequire github.com/gorilla/mux v1.8.0\n")
+
+	reqs, err := ParseGoModRequires(dir)
+	require.NoError(t, err)
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "github.com/gorilla/mux", reqs[0].Path)
+	assert.Equal(t, "v1.8.0", reqs[0].Version)
+}
+
+func TestParseGoModRequires_BlockFormWithIndirectComment(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", `module github.com/example/testapp
+
+go 1.21
+
+require (
+	github.com/gorilla/mux v1.8.0
+	github.com/lib/pq v1.10.0 // indirect
+)
+`)
+
+	reqs, err := ParseGoModRequires(dir)
+	require.NoError(t, err)
+	require.Len(t, reqs, 2)
+	assert.Equal(t, GoModRequire{Path: "github.com/gorilla/mux", Version: "v1.8.0"}, reqs[0])
+	assert.Equal(t, GoModRequire{Path: "github.com/lib/pq", Version: "v1.10.0"}, reqs[1])
+}
+
+func TestParseGoModRequires_NoRequireBlock(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n")
+
+	reqs, err := ParseGoModRequires(dir)
+	require.NoError(t, err)
+	assert.Empty(t, reqs)
+}
+
+// -----------------------------------------------------------------------------
+// ParseGoModExcludes / FilterExcludedRequires
+// -----------------------------------------------------------------------------
+
+func TestParseGoModExcludes_SingleLine(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n\nexclude github.com/gorilla/mux v1.7.0\n")
+
+	excludes, err := ParseGoModExcludes(dir)
+	require.NoError(t, err)
+	require.Len(t, excludes, 1)
+	assert.Equal(t, GoModExclude{Path: "github.com/gorilla/mux", Version: "v1.7.0"}, excludes[0])
+}
+
+func TestParseGoModExcludes_BlockForm(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", `module github.com/example/testapp
+
+go 1.21
+
+exclude (
+	github.com/gorilla/mux v1.7.0
+	github.com/lib/pq v1.9.0
+)
+`)
+
+	excludes, err := ParseGoModExcludes(dir)
+	require.NoError(t, err)
+	require.Len(t, excludes, 2)
+	assert.Equal(t, GoModExclude{Path: "github.com/gorilla/mux", Version: "v1.7.0"}, excludes[0])
+	assert.Equal(t, GoModExclude{Path: "github.com/lib/pq", Version: "v1.9.0"}, excludes[1])
+}
+
+func TestParseGoModExcludes_NoExcludeBlock(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n")
+
+	excludes, err := ParseGoModExcludes(dir)
+	require.NoError(t, err)
+	assert.Empty(t, excludes)
+}
+
+func TestFilterExcludedRequires_RemovesMatchingVersion(t *testing.T) {
+	requires := []GoModRequire{
+		{Path: "github.com/gorilla/mux", Version: "v1.7.0"},
+		{Path: "github.com/gorilla/mux", Version: "v1.8.0"},
+		{Path: "github.com/lib/pq", Version: "v1.10.0"},
+	}
+	excludes := []GoModExclude{{Path: "github.com/gorilla/mux", Version: "v1.7.0"}}
+
+	filtered := FilterExcludedRequires(requires, excludes)
+
+	assert.Equal(t, []GoModRequire{
+		{Path: "github.com/gorilla/mux", Version: "v1.8.0"},
+		{Path: "github.com/lib/pq", Version: "v1.10.0"},
+	}, filtered)
+}
+
+func TestFilterExcludedRequires_NoExcludes_ReturnsSameSlice(t *testing.T) {
+	requires := []GoModRequire{{Path: "github.com/gorilla/mux", Version: "v1.8.0"}}
+	assert.Equal(t, requires, FilterExcludedRequires(requires, nil))
+}
+
+// -----------------------------------------------------------------------------
+// GoImportResolver.RewriteReplacedImport — module→module replace rewriting
+// -----------------------------------------------------------------------------
+
+func TestRewriteReplacedImport_ExactModuleMatch(t *testing.T) {
+	r := NewGoImportResolver(nil).WithReplacements([]GoModReplace{
+		{Old: "github.com/foo/bar", NewPath: "github.com/baz", NewVersion: "v1.2.3"},
+	})
+
+	assert.Equal(t, "github.com/baz", r.RewriteReplacedImport("github.com/foo/bar"))
+}
+
+func TestRewriteReplacedImport_SubpackageMatch(t *testing.T) {
+	r := NewGoImportResolver(nil).WithReplacements([]GoModReplace{
+		{Old: "github.com/foo/bar", NewPath: "github.com/baz", NewVersion: "v1.2.3"},
+	})
+
+	assert.Equal(t, "github.com/baz/pkg", r.RewriteReplacedImport("github.com/foo/bar/pkg"))
+}
+
+func TestRewriteReplacedImport_NoMatch_ReturnsUnchanged(t *testing.T) {
+	r := NewGoImportResolver(nil).WithReplacements([]GoModReplace{
+		{Old: "github.com/foo/bar", NewPath: "github.com/baz", NewVersion: "v1.2.3"},
+	})
+
+	assert.Equal(t, "github.com/other/pkg", r.RewriteReplacedImport("github.com/other/pkg"))
+}
+
+func TestRewriteReplacedImport_LocalReplacementNotRewritten(t *testing.T) {
+	dir := t.TempDir()
+	replacements := []GoModReplace{{Old: "github.com/foo/bar", Local: true, NewDir: dir}}
+	r := NewGoImportResolver(nil).WithReplacements(replacements)
+
+	// Local replacements are resolved via DirToImport/ImportToDir, not rewritten here.
+	assert.Equal(t, "github.com/foo/bar", r.RewriteReplacedImport("github.com/foo/bar"))
+}
+
+// -----------------------------------------------------------------------------
+// ParseGoSumVersions
+// -----------------------------------------------------------------------------
+
+func TestParseGoSumVersions_SkipsGoModHashLines(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.sum", strings.Join([]string{
+		"github.com/gorilla/mux v1.8.0 h1:i40aqfkR1h2SlN9hojwV5ZA91wcXFOvkdNIeFDP5koI=",
+		"github.com/gorilla/mux v1.8.0/go.mod h1:DVbg23sWSpFRCP0SfiEN6jmj59UnW/n46BH5rLB71So=",
+		"github.com/lib/pq v1.10.0 h1:abc=",
+		"github.com/lib/pq v1.10.0/go.mod h1:def=",
+	}, "\n")+"\n")
+
+	versions, err := ParseGoSumVersions(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"github.com/gorilla/mux": "v1.8.0",
+		"github.com/lib/pq":      "v1.10.0",
+	}, versions)
+}
+
+func TestParseGoSumVersions_MissingFile(t *testing.T) {
+	versions, err := ParseGoSumVersions(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+// -----------------------------------------------------------------------------
+// ParseGoModReplacements / applyGoModReplacements
+// -----------------------------------------------------------------------------
+
+func TestParseGoModReplacements_LocalSingleLine(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n\nreplace github.com/foo/bar => ../bar\n")
+
+	reps, err := ParseGoModReplacements(dir)
+	require.NoError(t, err)
+	require.Len(t, reps, 1)
+	assert.Equal(t, "github.com/foo/bar", reps[0].Old)
+	assert.True(t, reps[0].Local)
+	assert.Equal(t, filepath.Clean(filepath.Join(dir, "../bar")), reps[0].NewDir)
+}
+
+func TestParseGoModReplacements_BlockForm(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", `module github.com/example/testapp
+
+go 1.21
+
+replace (
+	github.com/foo/bar => ../bar
+	github.com/old/mod => github.com/new/mod v1.2.3
+)
+`)
+
+	reps, err := ParseGoModReplacements(dir)
+	require.NoError(t, err)
+	require.Len(t, reps, 2)
+
+	assert.Equal(t, "github.com/foo/bar", reps[0].Old)
+	assert.True(t, reps[0].Local)
+
+	assert.Equal(t, "github.com/old/mod", reps[1].Old)
+	assert.False(t, reps[1].Local)
+	assert.Equal(t, "github.com/new/mod", reps[1].NewPath)
+	assert.Equal(t, "v1.2.3", reps[1].NewVersion)
+}
+
+func TestParseGoModReplacements_NoDirectives(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n")
+
+	reps, err := ParseGoModReplacements(dir)
+	require.NoError(t, err)
+	assert.Empty(t, reps)
+}
+
+func TestBuildGoModuleRegistry_AppliesLocalReplace(t *testing.T) {
+	root := t.TempDir()
+	writeTempFile(t, root, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n\nreplace github.com/foo/bar => ../bar\n")
+
+	barDir := filepath.Join(filepath.Dir(root), "bar")
+	require.NoError(t, os.MkdirAll(filepath.Join(barDir, "pkg"), 0o755))
+	writeTempFile(t, barDir, "go.mod", "module github.com/realbar\n\ngo 1.21\n")
+	t.Cleanup(func() { _ = os.RemoveAll(barDir) })
+
+	registry, err := BuildGoModuleRegistry(root)
+	require.NoError(t, err)
+
+	imp, ok := registry.DirToImport[filepath.Join(barDir, "pkg")]
+	require.True(t, ok, "expected replaced directory to be mapped under the old import path")
+	assert.Equal(t, "github.com/foo/bar/pkg", imp)
+}
+
+func TestGoImportResolver_WithReplacements_LocalReplaceIsLocal(t *testing.T) {
+	reg := &core.GoModuleRegistry{ModulePath: "github.com/example/testapp"}
+	r := NewGoImportResolver(reg).WithReplacements([]GoModReplace{
+		{Old: "github.com/foo/bar", Local: true, NewDir: "/tmp/bar"},
+		{Old: "github.com/old/mod", Local: false, NewPath: "github.com/new/mod"},
+	})
+
+	assert.Equal(t, ImportLocal, r.ClassifyImport("github.com/foo/bar/pkg"))
+	// Module→module replacements don't have local source, so not local.
+	assert.Equal(t, ImportThirdParty, r.ClassifyImport("github.com/old/mod"))
+}
+
+// -----------------------------------------------------------------------------
+// BuildGoWorkspaceRegistry — go.work multi-module workspaces
+// -----------------------------------------------------------------------------
+
+func TestBuildGoWorkspaceRegistry_UnionsSiblingModules(t *testing.T) {
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.21\n\nuse ./modA\nuse ./modB\n")
+
+	writeTempFile(t, ws, "modA/go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+	writeTempFile(t, ws, "modA/lib.go", "package moda\n")
+
+	writeTempFile(t, ws, "modB/go.mod", "module github.com/example/modb\n\ngo 1.21\n")
+	writeTempFile(t, ws, "modB/lib.go", "package modb\n")
+
+	registry, err := BuildGoWorkspaceRegistry(ws)
+	require.NoError(t, err)
+	require.Len(t, registry.Members, 2)
+
+	assert.Equal(t, "github.com/example/moda", registry.DirToImport[filepath.Join(ws, "modA")])
+	assert.Equal(t, "github.com/example/modb", registry.DirToImport[filepath.Join(ws, "modB")])
+	assert.Equal(t, filepath.Join(ws, "modA"), registry.ImportToDir["github.com/example/moda"])
+}
+
+func TestBuildGoWorkspaceRegistry_BlockForm(t *testing.T) {
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.21\n\nuse (\n\t./modA\n\t./modB\n)\n")
+	writeTempFile(t, ws, "modA/go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+	writeTempFile(t, ws, "modB/go.mod", "module github.com/example/modb\n\ngo 1.21\n")
+
+	registry, err := BuildGoWorkspaceRegistry(ws)
+	require.NoError(t, err)
+	assert.Len(t, registry.Members, 2)
+}
+
+func TestNewGoImportResolverForWorkspace_CrossModuleImportIsLocal(t *testing.T) {
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.21\n\nuse ./modA\nuse ./modB\n")
+	writeTempFile(t, ws, "modA/go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+	writeTempFile(t, ws, "modB/go.mod", "module github.com/example/modb\n\ngo 1.21\n")
+
+	registry, err := BuildGoWorkspaceRegistry(ws)
+	require.NoError(t, err)
+
+	modA := &core.GoModuleRegistry{ModulePath: "github.com/example/moda"}
+	r := NewGoImportResolverForWorkspace(modA, registry)
+
+	assert.Equal(t, ImportLocal, r.ClassifyImport("github.com/example/moda/lib"))
+	// Cross-module import within the same workspace is local too.
+	assert.Equal(t, ImportLocal, r.ClassifyImport("github.com/example/modb/lib"))
+	assert.Equal(t, ImportThirdParty, r.ClassifyImport("github.com/other/pkg"))
+}
+
+func TestWorkspace_CrossModuleCallResolvesToSiblingModuleFunctionScope(t *testing.T) {
+	ws := t.TempDir()
+	writeTempFile(t, ws, "go.work", "go 1.21\n\nuse ./modA\nuse ./modB\n")
+	writeTempFile(t, ws, "modA/go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+	writeTempFile(t, ws, "modA/lib.go", "package moda\n")
+	writeTempFile(t, ws, "modB/go.mod", "module github.com/example/modb\n\ngo 1.21\n")
+	writeTempFile(t, ws, "modB/lib.go", "package modb\n")
+
+	registry, err := BuildGoWorkspaceRegistry(ws)
+	require.NoError(t, err)
+
+	// modB declares a function, tracked in the shared inference engine under
+	// its own module's FQN.
+	engine := NewGoTypeInferenceEngine(nil)
+	scope := NewGoFunctionScope("github.com/example/modb.Helper")
+	engine.AddScope(scope)
+
+	// modA's import of modB resolves (via the workspace's unioned
+	// ImportToDir, the same mapping NewGoImportResolverForWorkspace draws
+	// its local-module list from) to modB's on-disk directory — demonstrating
+	// workspace-local resolution without going through GOPROXY.
+	modBDir, ok := registry.ImportToDir["github.com/example/modb"]
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(ws, "modB"), modBDir)
+
+	// A call site in modA targeting modb.Helper resolves, via the import
+	// path modA's resolver classifies as ImportLocal, to the function scope
+	// modB itself registered.
+	modA := &core.GoModuleRegistry{ModulePath: "github.com/example/moda"}
+	resolver := NewGoImportResolverForWorkspace(modA, registry)
+	importPath := "github.com/example/modb"
+	require.Equal(t, ImportLocal, resolver.ClassifyImport(importPath))
+
+	calleeFQN := importPath + ".Helper"
+	resolvedScope := engine.GetScope(calleeFQN)
+	require.NotNil(t, resolvedScope)
+	assert.Equal(t, "github.com/example/modb.Helper", resolvedScope.FunctionFQN)
+}
+
+func TestBuildGoModuleRegistry_AutoDetectsGoWork_CrossModuleImportIsLocal(t *testing.T) {
+	modADir := t.TempDir()
+	writeTempFile(t, modADir, "go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+	writeTempFile(t, modADir, "lib.go", "package moda\n")
+	// go.work lives at modA's own root alongside its go.mod — the common
+	// layout for a module that is itself a workspace root referencing a
+	// sibling module elsewhere on disk.
+	writeTempFile(t, modADir, "go.work", "go 1.21\n\nuse .\nuse ../modB\n")
+
+	modBDir := filepath.Join(filepath.Dir(modADir), "modB")
+	writeTempFile(t, modBDir, "go.mod", "module github.com/example/modb\n\ngo 1.21\n")
+	writeTempFile(t, modBDir, "lib.go", "package modb\n")
+	t.Cleanup(func() { _ = os.RemoveAll(modBDir) })
+
+	registry, err := BuildGoModuleRegistry(modADir)
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/example/moda", registry.ModulePath)
+
+	// The sibling module's directory/import mapping was folded in even
+	// though the caller only asked BuildGoModuleRegistry about modA.
+	assert.Equal(t, "github.com/example/modb", registry.DirToImport[modBDir])
+	assert.Contains(t, registry.WorkspaceModulePaths, "github.com/example/modb")
+
+	r := NewGoImportResolver(registry)
+	assert.Equal(t, ImportLocal, r.ClassifyImport("github.com/example/modb/lib"))
+	assert.Equal(t, ImportThirdParty, r.ClassifyImport("github.com/other/pkg"))
+}
+
+func TestBuildGoModuleRegistry_NoGoWork_WorkspaceModulePathsEmpty(t *testing.T) {
+	root := t.TempDir()
+	writeTempFile(t, root, "go.mod", "module github.com/example/solo\n\ngo 1.21\n")
+
+	registry, err := BuildGoModuleRegistry(root)
+	require.NoError(t, err)
+	assert.Empty(t, registry.WorkspaceModulePaths)
+}
+
+func TestBuildGoModuleRegistry_HonorsGoWorkReplace(t *testing.T) {
+	root := t.TempDir()
+	writeTempFile(t, root, "go.mod", "module github.com/example/moda\n\ngo 1.21\n")
+	writeTempFile(t, root, "go.work", "go 1.21\n\nuse .\n\nreplace github.com/foo/bar => ./vendored/bar\n")
+	writeTempFile(t, root, "vendored/bar/go.mod", "module github.com/realbar\n\ngo 1.21\n")
+	writeTempFile(t, root, "vendored/bar/pkg/lib.go", "package pkg\n")
+
+	registry, err := BuildGoModuleRegistry(root)
+	require.NoError(t, err)
+
+	imp, ok := registry.DirToImport[filepath.Join(root, "vendored/bar/pkg")]
+	require.True(t, ok, "expected go.work replace directive to be folded into the root registry")
+	assert.Equal(t, "github.com/foo/bar/pkg", imp)
+}
+
+// -----------------------------------------------------------------------------
+// Requires / Replaces / Excludes recorded directly on the registry
+// -----------------------------------------------------------------------------
+
+func TestBuildGoModuleRegistry_RecordsRequiresReplacesExcludes(t *testing.T) {
+	root := t.TempDir()
+	writeTempFile(t, root, "go.mod", strings.Join([]string{
+		"module github.com/example/testapp",
+		"",
+		"go 1.21",
+		"",
+		"require (",
+		"\tgithub.com/foo/bar v1.2.3",
+		"\tgithub.com/foo/baz v4.5.6 // indirect",
+		")",
+		"",
+		"exclude github.com/foo/broken v0.0.1",
+		"",
+		"replace github.com/foo/bar => ../bar",
+		"replace github.com/foo/old => github.com/foo/new v2.0.0",
+		"",
+	}, "\n"))
+
+	barDir := filepath.Join(filepath.Dir(root), "bar")
+	require.NoError(t, os.MkdirAll(barDir, 0o755))
+	writeTempFile(t, barDir, "go.mod", "module github.com/realbar\n\ngo 1.21\n")
+	t.Cleanup(func() { _ = os.RemoveAll(barDir) })
+
+	registry, err := BuildGoModuleRegistry(root)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.3", registry.Requires["github.com/foo/bar"])
+	assert.Equal(t, "v4.5.6", registry.Requires["github.com/foo/baz"])
+
+	assert.True(t, registry.Excludes["github.com/foo/broken"])
+	assert.False(t, registry.Excludes["github.com/foo/bar"])
+
+	localRep, ok := registry.Replaces["github.com/foo/bar"]
+	require.True(t, ok)
+	assert.True(t, localRep.Local)
+	assert.Equal(t, barDir, localRep.NewDir)
+
+	modRep, ok := registry.Replaces["github.com/foo/old"]
+	require.True(t, ok)
+	assert.False(t, modRep.Local)
+	assert.Equal(t, "github.com/foo/new", modRep.NewPath)
+	assert.Equal(t, "v2.0.0", modRep.NewVersion)
+}
+
+func TestGoImportResolver_LocalReplaceAutoClassifiesLocal(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		ModulePath: "github.com/example/testapp",
+		Replaces: map[string]core.Replacement{
+			"github.com/foo/bar": {Local: true, NewDir: "/tmp/bar"},
+			"github.com/foo/old": {NewPath: "github.com/foo/new", NewVersion: "v2.0.0"},
+		},
+	}
+	r := NewGoImportResolver(reg)
+
+	assert.Equal(t, ImportLocal, r.ClassifyImport("github.com/foo/bar/pkg"))
+	// A module→module replacement has no local source, so it stays third-party.
+	assert.Equal(t, ImportThirdParty, r.ClassifyImport("github.com/foo/old"))
+}
+
+func TestGoImportResolver_ResolveToDir(t *testing.T) {
+	root := t.TempDir()
+	writeTempFile(t, root, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n\nreplace github.com/foo/bar => ../bar\n")
+
+	barDir := filepath.Join(filepath.Dir(root), "bar")
+	require.NoError(t, os.MkdirAll(filepath.Join(barDir, "sub"), 0o755))
+	writeTempFile(t, barDir, "go.mod", "module github.com/realbar\n\ngo 1.21\n")
+	t.Cleanup(func() { _ = os.RemoveAll(barDir) })
+
+	registry, err := BuildGoModuleRegistry(root)
+	require.NoError(t, err)
+	r := NewGoImportResolver(registry)
+
+	dir, ok := r.ResolveToDir("github.com/foo/bar/sub")
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(barDir, "sub"), dir)
+
+	_, ok = r.ResolveToDir("github.com/unknown/pkg")
+	assert.False(t, ok)
+}
+
+func TestGoImportResolver_ResolveToDir_NilRegistry(t *testing.T) {
+	r := NewGoImportResolver(nil)
+	_, ok := r.ResolveToDir("github.com/foo/bar")
+	assert.False(t, ok)
+}
+
+func TestGoImportResolver_ResolveToDir_FallsBackToReplaceWhenDirNotWalked(t *testing.T) {
+	// The replacement target never existed on disk, so applyGoModReplacements
+	// had nothing to walk and ImportToDir carries no entry for it — only
+	// registry.Replaces records the directive, and ResolveToDir must still
+	// compute the answer from that.
+	reg := &core.GoModuleRegistry{
+		ModulePath:  "github.com/example/testapp",
+		DirToImport: map[string]string{},
+		ImportToDir: map[string]string{},
+		Replaces: map[string]core.Replacement{
+			"github.com/foo/bar": {Local: true, NewDir: "/tmp/bar"},
+		},
 	}
+	r := NewGoImportResolver(reg)
+
+	dir, ok := r.ResolveToDir("github.com/foo/bar/sub")
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join("/tmp/bar", "sub"), dir)
+}
+
+// -----------------------------------------------------------------------------
+// BuildVendorIndex / ImportVendored
+// -----------------------------------------------------------------------------
+
+func TestBuildVendorIndex_ParsesModulesTxt(t *testing.T) {
+	dir := t.TempDir()
+	writeTempFile(t, dir, "vendor/modules.txt", `# github.com/gorilla/mux v1.8.0
+## explicit
+github.com/gorilla/mux
+# github.com/lib/pq v1.10.0
+## explicit
+github.com/lib/pq
+`)
+
+	index, err := BuildVendorIndex(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "vendor", "github.com", "gorilla", "mux"), index["github.com/gorilla/mux"])
+	assert.Equal(t, filepath.Join(dir, "vendor", "github.com", "lib", "pq"), index["github.com/lib/pq"])
+}
+
+func TestBuildVendorIndex_NoVendorDir(t *testing.T) {
+	index, err := BuildVendorIndex(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, index)
+}
+
+func TestBuildGoModuleRegistry_AutoIndexesVendorDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeTempFile(t, root, "go.mod", "module github.com/example/testapp\n\ngo 1.21\n")
+	writeTempFile(t, root, "vendor/modules.txt", `# github.com/gorilla/mux v1.8.0
+## explicit
+github.com/gorilla/mux
+`)
+
+	registry, err := BuildGoModuleRegistry(root)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "vendor", "github.com", "gorilla", "mux"), registry.VendoredPackages["github.com/gorilla/mux"])
+
+	r := NewGoImportResolver(registry)
+	assert.Equal(t, ImportVendored, r.ClassifyImport("github.com/gorilla/mux"))
+
+	dir, ok := r.ResolveToDir("github.com/gorilla/mux")
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join(root, "vendor", "github.com", "gorilla", "mux"), dir)
+}
+
+func TestGoImportResolver_WithVendorIndex_ClassifiesVendored(t *testing.T) {
+	reg := &core.GoModuleRegistry{ModulePath: "github.com/example/testapp"}
+	r := NewGoImportResolver(reg).WithVendorIndex(map[string]string{
+		"github.com/gorilla/mux": "/proj/vendor/github.com/gorilla/mux",
+	})
+
+	assert.Equal(t, ImportVendored, r.ClassifyImport("github.com/gorilla/mux"))
+	assert.Equal(t, ImportThirdParty, r.ClassifyImport("github.com/lib/pq"))
+
+	resolved := r.Resolve("github.com/gorilla/mux")
+	assert.Equal(t, ImportVendored, resolved.Kind)
+	assert.Equal(t, "/proj/vendor/github.com/gorilla/mux", resolved.Dir)
+}
+
+func TestGoImportResolver_ResolveImportsDetailed(t *testing.T) {
+	reg := &core.GoModuleRegistry{ModulePath: "github.com/example/testapp"}
+	r := NewGoImportResolver(reg).WithVendorIndex(map[string]string{
+		"github.com/gorilla/mux": "/proj/vendor/github.com/gorilla/mux",
+	})
+
+	results := r.ResolveImportsDetailed([]string{"github.com/gorilla/mux", "fmt"})
+	assert.Equal(t, ImportVendored, results["github.com/gorilla/mux"].Kind)
+	assert.Equal(t, ImportStdlib, results["fmt"].Kind)
+	assert.Empty(t, results["fmt"].Dir)
 }
 
 func TestExtractGoImports(t *testing.T) {
@@ -218,7 +925,6 @@ func TestExtractLocalName(t *testing.T) {
 	}
 }
 
-
 func TestShouldSkipGoDirectory(t *testing.T) {
 	tests := []struct {
 		dirName string
@@ -0,0 +1,213 @@
+package resolution
+
+import (
+	"sort"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// BranchFrame identifies one control-structure branch a variable binding or
+// use-site sits inside, within its enclosing function: ControlStart is the
+// control node's own start byte (unique per instance, so two "if" statements
+// never collide), and Branch is the index of the control node's named child
+// that contains this position — for an if_statement, 0 is "consequence" and
+// 1 is "alternative"; for a switch/select, the index of the containing case
+// clause. A binding's/use-site's BranchPath is the ordered sequence of these
+// frames from the function body down to the binding itself, used by
+// GoFunctionScope.ReachingDefinitions to tell a straight-line predecessor
+// from a sibling-branch alternative that can only be reached by joining at
+// a merge point.
+type BranchFrame struct {
+	ControlStart uint32
+	Branch       int
+}
+
+// branchPathIsPrefix reports whether every frame in a matches b at the same
+// position — i.e. whether the binding at branch path a lies on every control
+// path that reaches branch path b (so a "dominates" b in the CFG sense).
+func branchPathIsPrefix(a, b []BranchFrame) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// commonBranchPrefixLen returns how many leading frames a and b share.
+func commonBranchPrefixLen(a, b []BranchFrame) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	i := 0
+	for i < n && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// NodeID locates a use-site within a function for GetVariableAt: StartByte is
+// the use-site's own byte offset and BranchPath its position in the
+// function's control-flow structure, exactly what computeBranchContext
+// (extraction package) already derives from a use-site's AST node. It's a
+// named pair rather than extraction passing the two separately, so
+// GetVariableAt reads as "resolve the variable at this CFG position" rather
+// than two loosely-related parameters.
+type NodeID struct {
+	StartByte  uint32
+	BranchPath []BranchFrame
+}
+
+// ReachingDefinitions resolves the set of bindings for varName that can
+// actually reach useByte/useBranchPath, and collapses them into a single
+// TypeInfo (see mergeReachingTypeInfos). This replaces "last binding in the
+// slice" with a flow-sensitive lookup: a binding only reaches a use when it
+// textually precedes it AND nothing on the path between them could have
+// overwritten it first.
+//
+// Known approximation: when only one side of a branch reassigns a variable
+// (e.g. `if cond { x = A() }` with no else), the other, non-reassigning
+// branch's implicit "x keeps its prior value" isn't modeled — this pass only
+// unions definitions it actually observed. For the motivating case in the
+// CHA request body (both branches assign), the result is exact.
+func (s *GoFunctionScope) ReachingDefinitions(varName string, useByte uint32, useBranchPath []BranchFrame) *core.TypeInfo {
+	binding := s.reachingDefinitionBinding(varName, useByte, useBranchPath)
+	if binding == nil {
+		return nil
+	}
+	return binding.Type
+}
+
+// GetVariableAt is ReachingDefinitions' full-binding counterpart: where
+// ReachingDefinitions only returns the resolved TypeInfo, GetVariableAt
+// returns the GoVariableBinding itself — the single dominating binding when
+// one exists, or a synthesized φ-binding (VarName set, Type the merged
+// union, PossibleTypes listing every contributing branch's type) at a join
+// point reached only by joining sibling branches. Returns nil exactly when
+// ReachingDefinitions would return nil: no binding for varName, or none that
+// reaches nodeID at all.
+func (s *GoFunctionScope) GetVariableAt(varName string, nodeID NodeID) *GoVariableBinding {
+	return s.reachingDefinitionBinding(varName, nodeID.StartByte, nodeID.BranchPath)
+}
+
+// reachingDefinitionBinding is the shared flow-sensitive lookup behind both
+// ReachingDefinitions (TypeInfo-only) and GetVariableAt (full binding).
+func (s *GoFunctionScope) reachingDefinitionBinding(varName string, useByte uint32, useBranchPath []BranchFrame) *GoVariableBinding {
+	bindings := s.Variables[varName]
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	var candidates []*GoVariableBinding
+	for _, b := range bindings {
+		if b.StartByte < useByte {
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Straight-line dominators: bindings on every path reaching the use.
+	// Among these, only the textually latest can still be live.
+	var dominating *GoVariableBinding
+	for _, b := range candidates {
+		if !branchPathIsPrefix(b.BranchPath, useBranchPath) {
+			continue
+		}
+		if dominating == nil || b.StartByte > dominating.StartByte {
+			dominating = b
+		}
+	}
+	if dominating != nil {
+		return dominating
+	}
+
+	// No dominator: the use is reached only by joining sibling branches of
+	// some ancestor control structure. Group the remaining candidates by
+	// which branch of that structure they sit in, keep the latest binding
+	// per branch, and union what's left.
+	type branchKey struct {
+		controlStart uint32
+		branch       int
+	}
+	latestPerBranch := make(map[branchKey]*GoVariableBinding)
+	for _, b := range candidates {
+		common := commonBranchPrefixLen(b.BranchPath, useBranchPath)
+		if common >= len(b.BranchPath) {
+			continue
+		}
+		key := branchKey{controlStart: b.BranchPath[common].ControlStart, branch: b.BranchPath[common].Branch}
+		if existing, ok := latestPerBranch[key]; !ok || b.StartByte > existing.StartByte {
+			latestPerBranch[key] = b
+		}
+	}
+	if len(latestPerBranch) == 0 {
+		return nil
+	}
+
+	// Union in source order, so the merged TypeFQN ("A|B") is deterministic.
+	branchBindings := make([]*GoVariableBinding, 0, len(latestPerBranch))
+	for _, b := range latestPerBranch {
+		branchBindings = append(branchBindings, b)
+	}
+	sort.Slice(branchBindings, func(i, j int) bool {
+		return branchBindings[i].StartByte < branchBindings[j].StartByte
+	})
+	merged := make([]*core.TypeInfo, 0, len(branchBindings))
+	for _, b := range branchBindings {
+		merged = append(merged, b.Type)
+	}
+	phiType := mergeReachingTypeInfos(merged)
+	if phiType == nil {
+		return nil
+	}
+	return &GoVariableBinding{
+		VarName:       varName,
+		Type:          phiType,
+		PossibleTypes: merged,
+		StartByte:     useByte,
+		BranchPath:    useBranchPath,
+	}
+}
+
+// mergeReachingTypeInfos collapses the reaching definitions that disagree at
+// a merge point into one TypeInfo: when every branch agrees on TypeFQN, that
+// type is returned as-is; otherwise the TypeFQNs are unioned ("A|B") and
+// Confidence is lowered to the weakest of the contributing branches, since
+// the actual runtime type now depends on which branch ran.
+func mergeReachingTypeInfos(infos []*core.TypeInfo) *core.TypeInfo {
+	if len(infos) == 0 {
+		return nil
+	}
+
+	var fqns []string
+	seen := make(map[string]bool)
+	minConfidence := infos[0].Confidence
+	for _, info := range infos {
+		if info == nil {
+			continue
+		}
+		if !seen[info.TypeFQN] {
+			seen[info.TypeFQN] = true
+			fqns = append(fqns, info.TypeFQN)
+		}
+		if info.Confidence < minConfidence {
+			minConfidence = info.Confidence
+		}
+	}
+
+	if len(fqns) == 1 {
+		return &core.TypeInfo{TypeFQN: fqns[0], Confidence: minConfidence, Source: "phi-merge"}
+	}
+
+	union := fqns[0]
+	for _, fqn := range fqns[1:] {
+		union += "|" + fqn
+	}
+	return &core.TypeInfo{TypeFQN: union, Confidence: minConfidence, Source: "phi-merge"}
+}
@@ -0,0 +1,136 @@
+package resolution
+
+import (
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReachingDefinitions_StraightLineOverwrite(t *testing.T) {
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{VarName: "x", StartByte: 10, Type: &core.TypeInfo{TypeFQN: "builtin.int"}})
+	scope.AddVariable(&GoVariableBinding{VarName: "x", StartByte: 20, Type: &core.TypeInfo{TypeFQN: "builtin.string"}})
+
+	result := scope.ReachingDefinitions("x", 30, nil)
+	require.NotNil(t, result)
+	assert.Equal(t, "builtin.string", result.TypeFQN)
+}
+
+func TestReachingDefinitions_IfElseBothAssign_UnionsAtMergePoint(t *testing.T) {
+	// if cond { x = A() } else { x = B() }; use(x) after the if.
+	ifStart := uint32(100)
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{
+		VarName:    "x",
+		StartByte:  110,
+		Type:       &core.TypeInfo{TypeFQN: "pkg.A", Confidence: 0.9},
+		BranchPath: []BranchFrame{{ControlStart: ifStart, Branch: 0}},
+	})
+	scope.AddVariable(&GoVariableBinding{
+		VarName:    "x",
+		StartByte:  150,
+		Type:       &core.TypeInfo{TypeFQN: "pkg.B", Confidence: 0.8},
+		BranchPath: []BranchFrame{{ControlStart: ifStart, Branch: 1}},
+	})
+
+	// Use-site is after the whole if statement, so its branch path is empty.
+	result := scope.ReachingDefinitions("x", 200, nil)
+	require.NotNil(t, result)
+	assert.Equal(t, "pkg.A|pkg.B", result.TypeFQN)
+	assert.InDelta(t, 0.8, float64(result.Confidence), 0.001)
+	assert.Equal(t, "phi-merge", result.Source)
+}
+
+func TestReachingDefinitions_UseInsideSameBranchSeesOnlyItsOwnBinding(t *testing.T) {
+	ifStart := uint32(100)
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{
+		VarName:    "x",
+		StartByte:  110,
+		Type:       &core.TypeInfo{TypeFQN: "pkg.A"},
+		BranchPath: []BranchFrame{{ControlStart: ifStart, Branch: 0}},
+	})
+	scope.AddVariable(&GoVariableBinding{
+		VarName:    "x",
+		StartByte:  150,
+		Type:       &core.TypeInfo{TypeFQN: "pkg.B"},
+		BranchPath: []BranchFrame{{ControlStart: ifStart, Branch: 1}},
+	})
+
+	// Use-site is still inside the "then" branch, after x's assignment there.
+	result := scope.ReachingDefinitions("x", 120, []BranchFrame{{ControlStart: ifStart, Branch: 0}})
+	require.NotNil(t, result)
+	assert.Equal(t, "pkg.A", result.TypeFQN)
+}
+
+func TestReachingDefinitions_NoPriorBinding(t *testing.T) {
+	scope := NewGoFunctionScope("test.F")
+	assert.Nil(t, scope.ReachingDefinitions("x", 100, nil))
+}
+
+func TestReachingDefinitions_OnlyLaterBindingExists(t *testing.T) {
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{VarName: "x", StartByte: 500, Type: &core.TypeInfo{TypeFQN: "builtin.int"}})
+
+	assert.Nil(t, scope.ReachingDefinitions("x", 10, nil))
+}
+
+func TestBranchPathIsPrefix(t *testing.T) {
+	a := []BranchFrame{{ControlStart: 1, Branch: 0}}
+	b := []BranchFrame{{ControlStart: 1, Branch: 0}, {ControlStart: 2, Branch: 1}}
+
+	assert.True(t, branchPathIsPrefix(a, b))
+	assert.True(t, branchPathIsPrefix(nil, b))
+	assert.False(t, branchPathIsPrefix(b, a))
+}
+
+func TestGetVariableAt_StraightLineReturnsDominatingBinding(t *testing.T) {
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{VarName: "x", StartByte: 10, Type: &core.TypeInfo{TypeFQN: "builtin.int"}})
+	scope.AddVariable(&GoVariableBinding{VarName: "x", StartByte: 20, Type: &core.TypeInfo{TypeFQN: "builtin.string"}})
+
+	binding := scope.GetVariableAt("x", NodeID{StartByte: 30})
+	require.NotNil(t, binding)
+	assert.Equal(t, "builtin.string", binding.Type.TypeFQN)
+}
+
+func TestGetVariableAt_JoinPointReturnsSynthesizedPhiBindingWithPossibleTypes(t *testing.T) {
+	ifStart := uint32(100)
+	scope := NewGoFunctionScope("test.F")
+	scope.AddVariable(&GoVariableBinding{
+		VarName:    "x",
+		StartByte:  110,
+		Type:       &core.TypeInfo{TypeFQN: "pkg.A", Confidence: 0.9},
+		BranchPath: []BranchFrame{{ControlStart: ifStart, Branch: 0}},
+	})
+	scope.AddVariable(&GoVariableBinding{
+		VarName:    "x",
+		StartByte:  150,
+		Type:       &core.TypeInfo{TypeFQN: "pkg.B", Confidence: 0.8},
+		BranchPath: []BranchFrame{{ControlStart: ifStart, Branch: 1}},
+	})
+
+	binding := scope.GetVariableAt("x", NodeID{StartByte: 200})
+	require.NotNil(t, binding)
+	assert.Equal(t, "x", binding.VarName)
+	assert.Equal(t, "pkg.A|pkg.B", binding.Type.TypeFQN)
+	assert.Equal(t, "phi-merge", binding.Type.Source)
+	require.Len(t, binding.PossibleTypes, 2)
+}
+
+func TestGetVariableAt_NoPriorBindingReturnsNil(t *testing.T) {
+	scope := NewGoFunctionScope("test.F")
+	assert.Nil(t, scope.GetVariableAt("x", NodeID{StartByte: 100}))
+}
+
+func TestMergeReachingTypeInfos_AgreeingTypesKeepConfidence(t *testing.T) {
+	result := mergeReachingTypeInfos([]*core.TypeInfo{
+		{TypeFQN: "pkg.A", Confidence: 0.9},
+		{TypeFQN: "pkg.A", Confidence: 0.7},
+	})
+	require.NotNil(t, result)
+	assert.Equal(t, "pkg.A", result.TypeFQN)
+	assert.InDelta(t, 0.7, float64(result.Confidence), 0.001)
+}
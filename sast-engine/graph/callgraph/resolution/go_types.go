@@ -31,6 +31,27 @@ type GoVariableBinding struct {
 
 	// Source location of assignment
 	Location Location
+
+	// StartByte is this assignment statement's own start byte in its file,
+	// used by ReachingDefinitions to order candidate bindings and test
+	// textual precedence against a use-site.
+	StartByte uint32
+
+	// BranchPath locates this binding within its function's control-flow
+	// structure (nested if/for/switch/select branches), used by
+	// ReachingDefinitions to tell a dominating predecessor from a
+	// sibling-branch alternative. Empty for a binding in the function's
+	// top-level straight-line code.
+	BranchPath []BranchFrame
+
+	// PossibleTypes is populated when Type.TypeFQN names an interface with
+	// known implementors (see BuildGoInterfaceCHA): it lists those concrete
+	// implementor types, so a caller building call-graph edges for a method
+	// call through this variable can fan out to every type that might
+	// actually run, rather than only seeing the interface's own FQN. Nil
+	// for a binding whose static type is concrete, or an interface with no
+	// CHA data yet.
+	PossibleTypes []*core.TypeInfo
 }
 
 // GoFunctionScope tracks variable type bindings within a single function.
@@ -116,20 +137,63 @@ type GoTypeInferenceEngine struct {
 	// Function FQN → return type
 	ReturnTypes map[string]*core.TypeInfo
 
+	// Function FQN → every declared return position, in order, for functions
+	// with more than one return value (see AddReturnTypes/GetReturnTypes).
+	// ReturnTypes above only ever holds the first non-error position, which
+	// is all GetReturnType needs for a single-assignment call but loses the
+	// other positions a tuple assignment (a, b, err := f()) needs to zip
+	// against its LHS identifiers.
+	tupleReturnTypes map[string][]*core.TypeInfo
+
 	// Go module registry (from Phase 1)
 	Registry *core.GoModuleRegistry
 
+	// Type FQN → its exported method set, computed once per type the first
+	// time it's seen via AddReturnType/AddScope and cached here for every
+	// call after (including a nil entry, for a type go/types couldn't
+	// compute a method set for).
+	methodSets map[string][]string
+
+	// Interface FQN → its exported method set, discovered lazily: the first
+	// time a type from a given package is indexed, every interface declared
+	// in that same package is folded in here.
+	knownInterfaces map[string][]string
+
+	// Packages already scanned for interface declarations, so re-indexing a
+	// type from a package already visited doesn't rescan it.
+	ifaceScannedPkgs map[string]bool
+
+	// Interface FQN → implementor type FQNs, and the reverse index, type FQN
+	// → interfaces it satisfies. Rebuilt from methodSets/knownInterfaces
+	// whenever either grows (see reindexInterfaceSatisfaction).
+	interfaceIndex map[string][]string
+	satisfiesIndex map[string][]string
+
+	// Callee FQN → the inferred argument types recorded for a call site
+	// targeting it (RecordCallArgs), consumed by GetReturnType's generic
+	// instantiation fallback.
+	callArgs map[string][]*core.TypeInfo
+
 	// Thread-safe access
-	scopeMutex sync.RWMutex
-	typeMutex  sync.RWMutex
+	scopeMutex    sync.RWMutex
+	typeMutex     sync.RWMutex
+	ifaceMutex    sync.RWMutex
+	callArgsMutex sync.RWMutex
 }
 
 // NewGoTypeInferenceEngine creates an initialized type inference engine.
 func NewGoTypeInferenceEngine(registry *core.GoModuleRegistry) *GoTypeInferenceEngine {
 	return &GoTypeInferenceEngine{
-		Scopes:      make(map[string]*GoFunctionScope),
-		ReturnTypes: make(map[string]*core.TypeInfo),
-		Registry:    registry,
+		Scopes:           make(map[string]*GoFunctionScope),
+		ReturnTypes:      make(map[string]*core.TypeInfo),
+		tupleReturnTypes: make(map[string][]*core.TypeInfo),
+		Registry:         registry,
+		methodSets:       make(map[string][]string),
+		knownInterfaces:  make(map[string][]string),
+		ifaceScannedPkgs: make(map[string]bool),
+		interfaceIndex:   make(map[string][]string),
+		satisfiesIndex:   make(map[string][]string),
+		callArgs:         make(map[string][]*core.TypeInfo),
 	}
 }
 
@@ -143,15 +207,31 @@ func (e *GoTypeInferenceEngine) GetScope(functionFQN string) *GoFunctionScope {
 	return e.Scopes[functionFQN]
 }
 
-// AddScope stores a function scope (thread-safe write).
+// AddScope stores a function scope (thread-safe write) and indexes every
+// variable binding's type against the module's known interfaces.
+//
+// GoFunctionScope.AddVariable has no reference back to the engine (bindings
+// are typically added to a scope before it's ever registered), so this is
+// where a scope's bindings actually get folded into
+// interfaceIndex/satisfiesIndex — the indexing this method performs is the
+// AddVariable half of the method-set/interface-satisfaction tracking described
+// on GoTypeInferenceEngine; AddReturnType performs the other half directly.
 // Ignores nil scopes.
 func (e *GoTypeInferenceEngine) AddScope(scope *GoFunctionScope) {
 	if scope == nil {
 		return
 	}
 	e.scopeMutex.Lock()
-	defer e.scopeMutex.Unlock()
 	e.Scopes[scope.FunctionFQN] = scope
+	e.scopeMutex.Unlock()
+
+	for _, bindings := range scope.Variables {
+		for _, binding := range bindings {
+			if binding.Type != nil {
+				e.indexTypeIfNew(binding.Type.TypeFQN)
+			}
+		}
+	}
 }
 
 // HasScope checks if a scope exists for a function.
@@ -179,7 +259,17 @@ func (e *GoTypeInferenceEngine) GetAllScopes() map[string]*GoFunctionScope {
 //
 // Lookup order:
 //  1. Locally-registered return types (user-code declarations populated during parsing).
-//  2. Go stdlib registry — when the engine's Registry has a StdlibLoader, the FQN is
+//  2. go/types — when the engine's Registry has a TypesChecker, the FQN is split
+//     into a package path and function name and checked against that package's
+//     go/types.Info. Returned with Confidence 1.0 and Source "go/types". This
+//     is the authoritative source for user code: unlike the stdlib manifest it
+//     correctly handles anonymous structs, embedded methods, and type aliases.
+//  3. Generic instantiation — when a call site targeting functionFQN has
+//     recorded its argument types (RecordCallArgs) and functionFQN declares
+//     type parameters, GetReturnTypeWithArgs substitutes them into the
+//     declared return type (e.g. resolving slices.Map[int, string]'s "[]U"
+//     to "builtin.string" from the caller's actual instantiation).
+//  4. Go stdlib registry — when the engine's Registry has a StdlibLoader, the FQN is
 //     split into an import path and function name and queried against the manifest.
 //     The first non-error, non-empty return type is returned with Confidence 1.0 and
 //     Source "stdlib".
@@ -194,21 +284,36 @@ func (e *GoTypeInferenceEngine) GetReturnType(functionFQN string) (*core.TypeInf
 		return typeInfo, true
 	}
 
-	// 2. Stdlib fallback.
-	if e.Registry == nil || e.Registry.StdlibLoader == nil {
+	pkgPath, funcName, ok := splitFunctionFQN(functionFQN)
+	if !ok {
 		return nil, false
 	}
-	dotIdx := strings.LastIndex(functionFQN, ".")
-	if dotIdx <= 0 {
-		return nil, false
+
+	// 2. go/types fallback.
+	if e.Registry != nil && e.Registry.TypesChecker != nil {
+		if typeInfo, ok := e.Registry.TypesChecker.FuncReturnType(pkgPath, funcName); ok {
+			return typeInfo, true
+		}
+	}
+
+	// 3. Generic instantiation fallback.
+	e.callArgsMutex.RLock()
+	argTypes, hasArgs := e.callArgs[functionFQN]
+	e.callArgsMutex.RUnlock()
+	if hasArgs {
+		if typeInfo, ok := e.GetReturnTypeWithArgs(functionFQN, argTypes); ok {
+			return typeInfo, true
+		}
 	}
-	importPath := functionFQN[:dotIdx]
-	funcName := functionFQN[dotIdx+1:]
 
-	if !e.Registry.StdlibLoader.ValidateStdlibImport(importPath) {
+	// 4. Stdlib fallback.
+	if e.Registry == nil || e.Registry.StdlibLoader == nil {
 		return nil, false
 	}
-	fn, err := e.Registry.StdlibLoader.GetFunction(importPath, funcName)
+	if !e.Registry.StdlibLoader.ValidateStdlibImport(pkgPath) {
+		return nil, false
+	}
+	fn, err := e.Registry.StdlibLoader.GetFunction(pkgPath, funcName)
 	if err != nil {
 		return nil, false
 	}
@@ -216,7 +321,14 @@ func (e *GoTypeInferenceEngine) GetReturnType(functionFQN string) (*core.TypeInf
 		if ret.Type == "" || ret.Type == "error" {
 			continue
 		}
-		typeFQN := stdlibNormalizeType(ret.Type, importPath)
+		if typeParamName(ret.Type, fn.TypeParams) != "" {
+			// Unresolved type parameter (e.g. slices.Max's "E") with no call-site
+			// argument types recorded for this FQN — GetReturnTypeWithArgs is the
+			// path that can actually substitute it. Qualifying it here would
+			// produce a nonsense FQN like "builtin.E" or "slices.E".
+			continue
+		}
+		typeFQN := stdlibNormalizeType(ret.Type, pkgPath)
 		if typeFQN == "" {
 			continue
 		}
@@ -229,6 +341,299 @@ func (e *GoTypeInferenceEngine) GetReturnType(functionFQN string) (*core.TypeInf
 	return nil, false
 }
 
+// GetExpressionType resolves the type of a single expression (e.g. a field
+// access like "cfg.Timeout") as it appears inside functionFQN's body, at
+// source line. Unlike GetReturnType, this has only one source: local scope
+// bindings and the stdlib manifest both track whole-function return types,
+// not arbitrary expressions, so a TypesChecker is the sole way the engine
+// can answer this.
+//
+// line is the 1-based source line the caller read expr's text from, passed
+// through to TypesChecker.ExpressionType so it can disambiguate a function
+// body where the same expression text appears more than once with different
+// resolved types (e.g. a name shadowed in an inner block by a variable of
+// another type) — matching by text alone would always return
+// whichever occurrence it happened to visit first.
+//
+// Returns (nil, false) when functionFQN doesn't split into a package/function
+// pair, no TypesChecker is configured, or expr doesn't resolve to a known
+// type at that line within that function.
+func (e *GoTypeInferenceEngine) GetExpressionType(functionFQN, expr string, line int) (*core.TypeInfo, bool) {
+	if e.Registry == nil || e.Registry.TypesChecker == nil {
+		return nil, false
+	}
+	pkgPath, funcName, ok := splitFunctionFQN(functionFQN)
+	if !ok {
+		return nil, false
+	}
+	return e.Registry.TypesChecker.ExpressionType(pkgPath, funcName, expr, line)
+}
+
+// splitFunctionFQN splits a "pkgPath.FuncName" FQN at its last dot. Returns
+// ok=false for a malformed FQN with no dot (or one at position 0, which
+// would yield an empty package path).
+func splitFunctionFQN(functionFQN string) (pkgPath, funcName string, ok bool) {
+	dotIdx := strings.LastIndex(functionFQN, ".")
+	if dotIdx <= 0 {
+		return "", "", false
+	}
+	return functionFQN[:dotIdx], functionFQN[dotIdx+1:], true
+}
+
+// ===== Generic Instantiation =====
+
+// RecordCallArgs stores the inferred argument types for a call site
+// targeting fqn (thread-safe write), keyed the same way ReturnTypes is keyed
+// by the declaring function's FQN. A later GetReturnType(fqn) substitutes
+// these into fqn's declared type parameters (via GetReturnTypeWithArgs) when
+// no local binding or go/types answer is available. Call once per call site
+// as it's extracted; a later call for the same fqn overwrites the recorded
+// arguments rather than accumulating them, since only the most recently
+// processed call site's instantiation is available to substitute.
+func (e *GoTypeInferenceEngine) RecordCallArgs(fqn string, argTypes []*core.TypeInfo) {
+	if fqn == "" {
+		return
+	}
+	e.callArgsMutex.Lock()
+	defer e.callArgsMutex.Unlock()
+	e.callArgs[fqn] = argTypes
+}
+
+// GetReturnTypeWithArgs resolves fqn's return type by unifying its declared
+// parameter types (GoStdlibFunction.Params) against argTypes — the actual
+// inferred type of each argument at one call site — to bind its type
+// parameters (GoStdlibFunction.TypeParams), then substitutes those bindings
+// into the declared return type.
+//
+// Concretely, for a slices.Map entry with Params ["[]T", "U"] and argTypes
+// [builtin.int, builtin.string]: "[]T" binds T to argTypes[0] as given
+// (builtin.int), "U" binds U to argTypes[1], and the declared return "[]U"
+// resolves to builtin.string. Only a parameter that typeParamName recognises
+// (a type parameter, optionally behind "*" or "[]") binds anything; a
+// parameter written as "func(T) U" binds neither T nor U.
+//
+// A declared return type that isn't a (possibly pointer/slice-wrapped)
+// reference to one of fqn's type parameters is resolved the same way the
+// plain stdlib fallback does, via stdlibNormalizeType — this is what lets a
+// non-generic return like sync.Pool.Get's "any" still resolve here, just at
+// a lower Confidence since nothing was actually unified for it.
+//
+// Returns (nil, false) when fqn can't be resolved to a known function, or
+// unification hits a conflicting instantiation (e.g. the same type
+// parameter bound to two different argument types across its parameter
+// list).
+func (e *GoTypeInferenceEngine) GetReturnTypeWithArgs(fqn string, argTypes []*core.TypeInfo) (*core.TypeInfo, bool) {
+	pkgPath, funcName, ok := splitFunctionFQN(fqn)
+	if !ok {
+		return nil, false
+	}
+	fn, ok := e.lookupFunctionEntry(pkgPath, funcName)
+	if !ok {
+		return nil, false
+	}
+
+	bindings, ok := unifyTypeParams(fn, argTypes)
+	if !ok {
+		return nil, false
+	}
+
+	for _, ret := range fn.Returns {
+		if ret.Type == "" || ret.Type == "error" {
+			continue
+		}
+		if varName := typeParamName(ret.Type, fn.TypeParams); varName != "" {
+			bound, ok := bindings[varName]
+			if !ok {
+				continue // type parameter never appeared in a unified argument position
+			}
+			return &core.TypeInfo{TypeFQN: bound.TypeFQN, Confidence: 1.0, Source: "generic"}, true
+		}
+		typeFQN := stdlibNormalizeType(ret.Type, pkgPath)
+		if typeFQN == "" {
+			continue
+		}
+		return &core.TypeInfo{TypeFQN: typeFQN, Confidence: 0.5, Source: "generic"}, true
+	}
+	return nil, false
+}
+
+// lookupFunctionEntry finds pkgPath.funcName's manifest entry, trying the
+// stdlib loader first and the third-party (GOMODCACHE) loader second — the
+// same two sources externalInfoForFQN picks between by is_stdlib, tried here
+// in sequence since GetReturnTypeWithArgs doesn't carry that flag.
+func (e *GoTypeInferenceEngine) lookupFunctionEntry(pkgPath, funcName string) (*core.GoStdlibFunction, bool) {
+	if e.Registry == nil {
+		return nil, false
+	}
+	if e.Registry.StdlibLoader != nil && e.Registry.StdlibLoader.ValidateStdlibImport(pkgPath) {
+		if fn, err := e.Registry.StdlibLoader.GetFunction(pkgPath, funcName); err == nil {
+			return fn, true
+		}
+	}
+	if e.Registry.ThirdPartyLoader != nil && e.Registry.ThirdPartyLoader.ValidateModuleImport(pkgPath) {
+		if fn, err := e.Registry.ThirdPartyLoader.GetFunction(pkgPath, funcName); err == nil {
+			return fn, true
+		}
+	}
+	return nil, false
+}
+
+// unifyTypeParams walks fn.Params in parallel with argTypes, binding each
+// type parameter named in a declared parameter to the corresponding
+// argument's inferred type. A parameter position with fewer supplied
+// argTypes, or whose declared type doesn't reference a type parameter, is
+// simply skipped — only a genuine conflict (the same type parameter bound to
+// two different argument types across the parameter list) fails unification.
+func unifyTypeParams(fn *core.GoStdlibFunction, argTypes []*core.TypeInfo) (map[string]*core.TypeInfo, bool) {
+	bindings := make(map[string]*core.TypeInfo, len(fn.TypeParams))
+	for i, declParam := range fn.Params {
+		if i >= len(argTypes) || argTypes[i] == nil {
+			continue
+		}
+		varName := typeParamName(declParam, fn.TypeParams)
+		if varName == "" {
+			continue
+		}
+		if existing, bound := bindings[varName]; bound {
+			if existing.TypeFQN != argTypes[i].TypeFQN {
+				return nil, false
+			}
+			continue
+		}
+		bindings[varName] = argTypes[i]
+	}
+	return bindings, true
+}
+
+// typeParamName reports whether declared (a textual type expression like
+// "E", "[]E", or "*E") refers to one of typeParams by name once a single
+// leading "*" or "[]" is stripped — the same container syntax
+// stdlibNormalizeType already strips for non-generic types. Returns "" when
+// declared isn't a reference to any of typeParams.
+func typeParamName(declared string, typeParams []core.GoTypeParam) string {
+	name := strings.TrimPrefix(declared, "*")
+	name = strings.TrimPrefix(name, "[]")
+	for _, tp := range typeParams {
+		if tp.Name == name {
+			return name
+		}
+	}
+	return ""
+}
+
+// ===== Method Set / Interface Satisfaction =====
+
+// InterfacesFor returns the interfaces typeFQN is currently known to
+// satisfy. "Currently known" because satisfaction is only checked against
+// interfaces go/types has turned up in a package some indexed type belongs
+// to — an interface declared in a package no return type or variable
+// binding has ever touched won't appear here until one does.
+func (e *GoTypeInferenceEngine) InterfacesFor(typeFQN string) []string {
+	e.ifaceMutex.RLock()
+	defer e.ifaceMutex.RUnlock()
+	out := make([]string, len(e.satisfiesIndex[typeFQN]))
+	copy(out, e.satisfiesIndex[typeFQN])
+	return out
+}
+
+// ImplementorsOf returns the concrete types currently known to implement
+// ifaceFQN (see InterfacesFor for the same "currently known" caveat). This
+// is what lets call-graph construction resolve an interface method call
+// like w.Write(...) on an io.Writer-typed binding to the concrete types
+// that actually implement it, instead of dropping the edge.
+func (e *GoTypeInferenceEngine) ImplementorsOf(ifaceFQN string) []string {
+	e.ifaceMutex.RLock()
+	defer e.ifaceMutex.RUnlock()
+	out := make([]string, len(e.interfaceIndex[ifaceFQN]))
+	copy(out, e.interfaceIndex[ifaceFQN])
+	return out
+}
+
+// indexTypeIfNew computes typeFQN's method set (via the engine's
+// TypesChecker) the first time it's seen, and folds in every interface
+// declared in typeFQN's own package the first time a type from that package
+// is indexed. Every method set and interface gathered so far is then
+// re-checked against each other, so an interface discovered via one type
+// also picks up implementors that were indexed earlier from a different
+// package.
+//
+// A no-op for a type already indexed, a builtin.* type (which has no
+// methods), an FQN that doesn't split into a package/type pair, or when the
+// engine has no TypesChecker configured.
+func (e *GoTypeInferenceEngine) indexTypeIfNew(typeFQN string) {
+	if typeFQN == "" || strings.HasPrefix(typeFQN, "builtin.") {
+		return
+	}
+
+	e.ifaceMutex.Lock()
+	defer e.ifaceMutex.Unlock()
+
+	if _, done := e.methodSets[typeFQN]; done {
+		return
+	}
+
+	pkgPath, typeName, ok := splitFunctionFQN(typeFQN)
+	if !ok || e.Registry == nil || e.Registry.TypesChecker == nil {
+		e.methodSets[typeFQN] = nil
+		return
+	}
+
+	methods, _ := e.Registry.TypesChecker.MethodSet(pkgPath, typeName)
+	e.methodSets[typeFQN] = methods
+
+	if !e.ifaceScannedPkgs[pkgPath] {
+		e.ifaceScannedPkgs[pkgPath] = true
+		for ifaceFQN, ifaceMethods := range e.Registry.TypesChecker.Interfaces(pkgPath) {
+			e.knownInterfaces[ifaceFQN] = ifaceMethods
+		}
+	}
+
+	e.reindexInterfaceSatisfaction()
+}
+
+// reindexInterfaceSatisfaction rebuilds interfaceIndex/satisfiesIndex from
+// scratch against the current methodSets/knownInterfaces. Callers must hold
+// ifaceMutex. A full rebuild (rather than incrementally diffing) keeps this
+// correct by construction whenever either map grows, at the cost of
+// re-comparing every type against every interface on each call — cheap
+// relative to the go/types checking indexTypeIfNew already did to get here.
+func (e *GoTypeInferenceEngine) reindexInterfaceSatisfaction() {
+	interfaceIndex := make(map[string][]string, len(e.interfaceIndex))
+	satisfiesIndex := make(map[string][]string, len(e.satisfiesIndex))
+	for typeFQN, methods := range e.methodSets {
+		for ifaceFQN, ifaceMethods := range e.knownInterfaces {
+			if !methodSetSatisfies(methods, ifaceMethods) {
+				continue
+			}
+			interfaceIndex[ifaceFQN] = append(interfaceIndex[ifaceFQN], typeFQN)
+			satisfiesIndex[typeFQN] = append(satisfiesIndex[typeFQN], ifaceFQN)
+		}
+	}
+	e.interfaceIndex = interfaceIndex
+	e.satisfiesIndex = satisfiesIndex
+}
+
+// methodSetSatisfies reports whether methods (a concrete type's exported
+// method set) is a superset of ifaceMethods (an interface's required exported
+// methods). An interface with no exported methods (e.g. the empty interface)
+// is never considered satisfied — it isn't a meaningful constraint to index
+// every type against.
+func methodSetSatisfies(methods, ifaceMethods []string) bool {
+	if len(ifaceMethods) == 0 {
+		return false
+	}
+	has := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		has[m] = true
+	}
+	for _, m := range ifaceMethods {
+		if !has[m] {
+			return false
+		}
+	}
+	return true
+}
+
 // AddReturnType stores return type for a function (thread-safe write).
 // Ignores nil type info.
 func (e *GoTypeInferenceEngine) AddReturnType(functionFQN string, typeInfo *core.TypeInfo) {
@@ -236,8 +641,87 @@ func (e *GoTypeInferenceEngine) AddReturnType(functionFQN string, typeInfo *core
 		return
 	}
 	e.typeMutex.Lock()
-	defer e.typeMutex.Unlock()
 	e.ReturnTypes[functionFQN] = typeInfo
+	e.typeMutex.Unlock()
+
+	e.indexTypeIfNew(typeInfo.TypeFQN)
+}
+
+// AddReturnTypes stores a function's full, ordered return-value list
+// (thread-safe write), for multi-return functions where a tuple assignment
+// (a, b, err := f()) needs each LHS zipped against its own position rather
+// than the single first-non-error type AddReturnType records. A nil entry
+// at a position (e.g. a named-but-unresolved blank return) is preserved —
+// callers zip positionally and skip nils, same as GetReturnTypes' stdlib
+// fallback already does. Ignores a types slice shorter than two entries,
+// since a single-valued return has no tuple to zip.
+//
+// There is no extraction pass yet that scans an arbitrary user function's
+// declaration and calls this for its own sake — unlike AddReturnType, which
+// extraction.inferTypeFromFuncLiteral calls for single-return func literals,
+// a func literal with two or more declared results is resolved directly off
+// its binding's Signature (see extraction.resolveFunctionVariableTupleCall)
+// rather than through this map. Today AddReturnTypes exists for callers that
+// already know a function's full signature by some other means (tests
+// seeding a known multi-return function being the only current caller) to
+// seed GetReturnTypes without waiting on that future pass.
+func (e *GoTypeInferenceEngine) AddReturnTypes(functionFQN string, types []*core.TypeInfo) {
+	if len(types) < 2 {
+		return
+	}
+	e.typeMutex.Lock()
+	e.tupleReturnTypes[functionFQN] = types
+	e.typeMutex.Unlock()
+}
+
+// GetReturnTypes resolves every positional return value of functionFQN, for
+// zipping against a multi-assignment's LHS identifiers. Checks, in order:
+//
+//  1. Local tuple registration (AddReturnTypes) — see that method's doc
+//     comment for what does and doesn't populate it today.
+//  2. Go stdlib registry — when the engine's Registry has a StdlibLoader,
+//     expands fn.Returns position by position (an "error" or unresolvable
+//     position is left nil, matching extraction.InferTupleFromStdlibFunction's
+//     contract so both sources zip the same way).
+//
+// Returns (nil, false) when neither source has at least two declared
+// returns for functionFQN — callers should fall back to GetReturnType's
+// single-type result (same type for every LHS) in that case.
+func (e *GoTypeInferenceEngine) GetReturnTypes(functionFQN string) ([]*core.TypeInfo, bool) {
+	e.typeMutex.RLock()
+	types, ok := e.tupleReturnTypes[functionFQN]
+	e.typeMutex.RUnlock()
+	if ok {
+		return types, true
+	}
+
+	pkgPath, funcName, ok := splitFunctionFQN(functionFQN)
+	if !ok {
+		return nil, false
+	}
+	if e.Registry == nil || e.Registry.StdlibLoader == nil {
+		return nil, false
+	}
+	if !e.Registry.StdlibLoader.ValidateStdlibImport(pkgPath) {
+		return nil, false
+	}
+	fn, err := e.Registry.StdlibLoader.GetFunction(pkgPath, funcName)
+	if err != nil || fn == nil || len(fn.Returns) < 2 {
+		return nil, false
+	}
+
+	resolved := make([]*core.TypeInfo, len(fn.Returns))
+	for i, ret := range fn.Returns {
+		if ret.Type == "" || ret.Type == "error" {
+			continue
+		}
+		typeFQN := stdlibNormalizeType(ret.Type, pkgPath)
+		if typeFQN == "" {
+			continue
+		}
+		resolved[i] = &core.TypeInfo{TypeFQN: typeFQN, Confidence: 1.0, Source: "stdlib"}
+	}
+	return resolved, true
 }
 
 // HasReturnType checks if a return type exists for a function.
@@ -283,7 +767,7 @@ func stdlibNormalizeType(rawType, importPath string) string {
 	case "string", "int", "int8", "int16", "int32", "int64",
 		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
 		"float32", "float64", "complex64", "complex128",
-		"bool", "byte", "rune", "error":
+		"bool", "byte", "rune", "error", "any":
 		return "builtin." + t
 	}
 	// Cross-package reference already qualified (e.g., "io.Reader").
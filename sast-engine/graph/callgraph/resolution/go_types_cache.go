@@ -0,0 +1,217 @@
+package resolution
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// snapshotFormatVersion is bumped whenever fileSnapshot's shape changes, so a
+// cache written by an older version is recognized as incompatible and
+// discarded rather than mis-decoded.
+const snapshotFormatVersion = 1
+
+// SnapshotStore persists a GoTypeInferenceEngine's inferred scopes and return
+// types to disk so a later run against an unchanged source tree can skip
+// re-inference for the functions it already has answers for.
+// GoTypeInferenceEngine implements this directly (see Save/Load).
+type SnapshotStore interface {
+	Save(path string) error
+	Load(path string) error
+}
+
+// fileSnapshot is the gob-encoded on-disk representation of one engine's
+// state. FileHashes records a SHA-256 digest per source file referenced by
+// a binding in Scopes, taken at Save time; Load recomputes each file's
+// current digest and skips any scope referencing a file whose digest no
+// longer matches, rather than trusting a snapshot that may have gone stale.
+type fileSnapshot struct {
+	Version     int
+	ModulePath  string
+	Scopes      map[string]*GoFunctionScope
+	ReturnTypes map[string]*core.TypeInfo
+	FileHashes  map[string]string
+}
+
+// Save serializes the engine's Scopes, ReturnTypes, and module path to path
+// as a versioned gob snapshot. The write is atomic: the encoded snapshot is
+// written to a temp file alongside path and then renamed into place, so a
+// crash mid-write (or a concurrent Load) never observes a partial file.
+func (e *GoTypeInferenceEngine) Save(path string) error {
+	e.scopeMutex.RLock()
+	scopes := make(map[string]*GoFunctionScope, len(e.Scopes))
+	maps.Copy(scopes, e.Scopes)
+	e.scopeMutex.RUnlock()
+
+	e.typeMutex.RLock()
+	returnTypes := make(map[string]*core.TypeInfo, len(e.ReturnTypes))
+	maps.Copy(returnTypes, e.ReturnTypes)
+	e.typeMutex.RUnlock()
+
+	var modulePath string
+	if e.Registry != nil {
+		modulePath = e.Registry.ModulePath
+	}
+
+	snapshot := fileSnapshot{
+		Version:     snapshotFormatVersion,
+		ModulePath:  modulePath,
+		Scopes:      scopes,
+		ReturnTypes: returnTypes,
+		FileHashes:  hashScopeSourceFiles(scopes),
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(snapshot); err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, ".go-types-snapshot-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp snapshot file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp snapshot file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp snapshot file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename temp snapshot file: %w", err)
+	}
+	return nil
+}
+
+// Load reads a snapshot previously written by Save and merges its return
+// types and non-stale scopes into the engine (via AddReturnType/AddScope, so
+// interface indexing stays consistent with a freshly-inferred engine). A
+// scope referencing a source file whose current SHA-256 digest no longer
+// matches FileHashes is dropped — the caller is expected to re-run inference
+// for that function's FQN itself, same as on a cold cache.
+//
+// A missing path, an unreadable or corrupt file, a Version mismatch, or a
+// snapshot recorded for a different module path are all treated as a cold
+// cache: Load returns nil without modifying the engine rather than failing
+// the run over a cache that's merely absent or no longer applicable.
+func (e *GoTypeInferenceEngine) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return nil
+	}
+
+	var snapshot fileSnapshot
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snapshot); err != nil {
+		return nil
+	}
+	if snapshot.Version != snapshotFormatVersion {
+		return nil
+	}
+	if e.Registry != nil && e.Registry.ModulePath != "" && snapshot.ModulePath != e.Registry.ModulePath {
+		return nil
+	}
+
+	for _, scope := range snapshot.Scopes {
+		if scopeIsStale(scope, snapshot.FileHashes) {
+			continue
+		}
+		e.AddScope(scope)
+	}
+	for fqn, typeInfo := range snapshot.ReturnTypes {
+		e.AddReturnType(fqn, typeInfo)
+	}
+	return nil
+}
+
+// NewGoTypeInferenceEngineWithCache creates an engine the same way
+// NewGoTypeInferenceEngine does and then attempts to Load cachePath into it.
+// A load failure (cold cache, stale format, unreadable file) is silently
+// ignored — exactly as Load itself treats those cases — so callers always
+// get a usable, if possibly empty, engine back.
+//
+// This is a separate constructor rather than an added parameter on
+// NewGoTypeInferenceEngine so the dozens of existing call sites that only
+// ever want an in-memory engine are unaffected.
+func NewGoTypeInferenceEngineWithCache(registry *core.GoModuleRegistry, cachePath string) *GoTypeInferenceEngine {
+	engine := NewGoTypeInferenceEngine(registry)
+	_ = engine.Load(cachePath)
+	return engine
+}
+
+// hashScopeSourceFiles computes a SHA-256 digest for every distinct source
+// file referenced by a binding's Location across scopes. A file that can no
+// longer be read (moved, deleted since inference ran) is recorded as "" so a
+// later scopeIsStale check always treats it as stale rather than panicking
+// or silently trusting a vanished file.
+func hashScopeSourceFiles(scopes map[string]*GoFunctionScope) map[string]string {
+	files := make(map[string]struct{})
+	for _, scope := range scopes {
+		for _, bindings := range scope.Variables {
+			for _, binding := range bindings {
+				if binding.Location.File != "" {
+					files[binding.Location.File] = struct{}{}
+				}
+			}
+		}
+	}
+
+	hashes := make(map[string]string, len(files))
+	for file := range files {
+		hash, err := hashFile(file)
+		if err != nil {
+			hashes[file] = ""
+			continue
+		}
+		hashes[file] = hash
+	}
+	return hashes
+}
+
+// scopeIsStale reports whether any source file referenced by scope's
+// variable bindings has changed (or vanished) since savedHashes was
+// recorded.
+func scopeIsStale(scope *GoFunctionScope, savedHashes map[string]string) bool {
+	for _, bindings := range scope.Variables {
+		for _, binding := range bindings {
+			if binding.Location.File == "" {
+				continue
+			}
+			saved, ok := savedHashes[binding.Location.File]
+			if !ok || saved == "" {
+				return true
+			}
+			current, err := hashFile(binding.Location.File)
+			if err != nil || current != saved {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hashFile returns the hex-encoded SHA-256 digest of path's contents.
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
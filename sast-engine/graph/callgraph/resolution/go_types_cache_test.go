@@ -0,0 +1,185 @@
+package resolution
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoad_RoundTripsReturnTypes(t *testing.T) {
+	reg := &core.GoModuleRegistry{ModulePath: "myapp"}
+	engine := NewGoTypeInferenceEngine(reg)
+	engine.AddReturnType("myapp.GetUser", &core.TypeInfo{TypeFQN: "myapp.User", Confidence: 1.0, Source: "declaration"})
+
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+	require.NoError(t, engine.Save(path))
+
+	loaded := NewGoTypeInferenceEngine(reg)
+	require.NoError(t, loaded.Load(path))
+
+	info, ok := loaded.GetReturnType("myapp.GetUser")
+	require.True(t, ok)
+	assert.Equal(t, "myapp.User", info.TypeFQN)
+}
+
+func TestSaveLoad_ScopeWithUnchangedFileIsLoaded(t *testing.T) {
+	dir := t.TempDir()
+	srcFile := filepath.Join(dir, "handler.go")
+	require.NoError(t, os.WriteFile(srcFile, []byte("package myapp\n"), 0o600))
+
+	reg := &core.GoModuleRegistry{ModulePath: "myapp"}
+	engine := NewGoTypeInferenceEngine(reg)
+	scope := NewGoFunctionScope("myapp.Handle")
+	scope.AddVariable(&GoVariableBinding{
+		VarName:  "user",
+		Type:     &core.TypeInfo{TypeFQN: "myapp.User"},
+		Location: Location{File: srcFile, Line: 5},
+	})
+	engine.AddScope(scope)
+
+	path := filepath.Join(dir, "snapshot.gob")
+	require.NoError(t, engine.Save(path))
+
+	loaded := NewGoTypeInferenceEngine(reg)
+	require.NoError(t, loaded.Load(path))
+
+	retrieved := loaded.GetScope("myapp.Handle")
+	require.NotNil(t, retrieved)
+	assert.True(t, retrieved.HasVariable("user"))
+}
+
+func TestSaveLoad_ScopeWithChangedFileIsInvalidated(t *testing.T) {
+	dir := t.TempDir()
+	srcFile := filepath.Join(dir, "handler.go")
+	require.NoError(t, os.WriteFile(srcFile, []byte("package myapp\n"), 0o600))
+
+	reg := &core.GoModuleRegistry{ModulePath: "myapp"}
+	engine := NewGoTypeInferenceEngine(reg)
+	scope := NewGoFunctionScope("myapp.Handle")
+	scope.AddVariable(&GoVariableBinding{
+		VarName:  "user",
+		Type:     &core.TypeInfo{TypeFQN: "myapp.User"},
+		Location: Location{File: srcFile, Line: 5},
+	})
+	engine.AddScope(scope)
+
+	path := filepath.Join(dir, "snapshot.gob")
+	require.NoError(t, engine.Save(path))
+
+	// Source file changes after the snapshot was taken.
+	require.NoError(t, os.WriteFile(srcFile, []byte("package myapp\n\n// changed\n"), 0o600))
+
+	loaded := NewGoTypeInferenceEngine(reg)
+	require.NoError(t, loaded.Load(path))
+
+	assert.Nil(t, loaded.GetScope("myapp.Handle"))
+}
+
+func TestLoad_MissingFile_ReturnsNilWithoutError(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(nil)
+	err := engine.Load(filepath.Join(t.TempDir(), "does-not-exist.gob"))
+	assert.NoError(t, err)
+	assert.Empty(t, engine.GetAllScopes())
+}
+
+func TestLoad_CorruptFile_ReturnsNilWithoutError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupt.gob")
+	require.NoError(t, os.WriteFile(path, []byte("not a valid gob stream"), 0o600))
+
+	engine := NewGoTypeInferenceEngine(nil)
+	err := engine.Load(path)
+	assert.NoError(t, err)
+}
+
+func TestLoad_DifferentModulePath_TreatedAsColdCache(t *testing.T) {
+	reg := &core.GoModuleRegistry{ModulePath: "myapp"}
+	engine := NewGoTypeInferenceEngine(reg)
+	engine.AddReturnType("myapp.GetUser", &core.TypeInfo{TypeFQN: "myapp.User"})
+
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+	require.NoError(t, engine.Save(path))
+
+	otherReg := &core.GoModuleRegistry{ModulePath: "otherapp"}
+	loaded := NewGoTypeInferenceEngine(otherReg)
+	require.NoError(t, loaded.Load(path))
+
+	_, ok := loaded.GetReturnType("myapp.GetUser")
+	assert.False(t, ok)
+}
+
+func TestSave_AtomicWrite_NoTempFileLeftBehind(t *testing.T) {
+	dir := t.TempDir()
+	engine := NewGoTypeInferenceEngine(nil)
+	engine.AddReturnType("myapp.GetUser", &core.TypeInfo{TypeFQN: "myapp.User"})
+
+	path := filepath.Join(dir, "snapshot.gob")
+	require.NoError(t, engine.Save(path))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "snapshot.gob", entries[0].Name())
+}
+
+func TestNewGoTypeInferenceEngineWithCache_LoadsExistingSnapshot(t *testing.T) {
+	reg := &core.GoModuleRegistry{ModulePath: "myapp"}
+	engine := NewGoTypeInferenceEngine(reg)
+	engine.AddReturnType("myapp.GetUser", &core.TypeInfo{TypeFQN: "myapp.User"})
+
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+	require.NoError(t, engine.Save(path))
+
+	loaded := NewGoTypeInferenceEngineWithCache(reg, path)
+	info, ok := loaded.GetReturnType("myapp.GetUser")
+	require.True(t, ok)
+	assert.Equal(t, "myapp.User", info.TypeFQN)
+}
+
+func TestNewGoTypeInferenceEngineWithCache_MissingFileYieldsUsableEngine(t *testing.T) {
+	engine := NewGoTypeInferenceEngineWithCache(nil, filepath.Join(t.TempDir(), "missing.gob"))
+	assert.NotNil(t, engine)
+	assert.Empty(t, engine.GetAllReturnTypes())
+}
+
+func TestSaveLoad_ConcurrentSafety(t *testing.T) {
+	reg := &core.GoModuleRegistry{ModulePath: "myapp"}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	var wg sync.WaitGroup
+	numGoroutines := 50
+	for i := range numGoroutines {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fqn := filepath.Join("myapp", "Func")
+			engine.AddReturnType(fqn, &core.TypeInfo{TypeFQN: "myapp.Type"})
+		}(i)
+	}
+	wg.Wait()
+
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+
+	var saveWg sync.WaitGroup
+	errs := make([]error, numGoroutines)
+	for i := range numGoroutines {
+		saveWg.Add(1)
+		go func(i int) {
+			defer saveWg.Done()
+			errs[i] = engine.Save(path)
+		}(i)
+	}
+	saveWg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	loaded := NewGoTypeInferenceEngine(reg)
+	require.NoError(t, loaded.Load(path))
+	assert.NotEmpty(t, loaded.GetAllReturnTypes())
+}
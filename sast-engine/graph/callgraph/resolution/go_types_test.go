@@ -8,6 +8,7 @@ import (
 
 	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // errNotFound is returned by mockStdlibLoader for unknown packages/functions.
@@ -40,6 +41,34 @@ func (m *mockGoTypesStdlibLoader) PackageCount() int {
 	return len(m.packages)
 }
 
+// mockTypesChecker implements core.TypesChecker for testing GetReturnType's
+// and GetExpressionType's go/types fallback without a real go/types.Config.Check.
+type mockTypesChecker struct {
+	returnTypes map[string]*core.TypeInfo      // key: "pkgPath.funcName"
+	exprTypes   map[string]*core.TypeInfo      // key: "pkgPath.funcName.expr"
+	methodSets  map[string][]string            // key: "pkgPath.typeName"
+	interfaces  map[string]map[string][]string // key: pkgPath
+}
+
+func (m *mockTypesChecker) FuncReturnType(pkgPath, funcName string) (*core.TypeInfo, bool) {
+	info, ok := m.returnTypes[pkgPath+"."+funcName]
+	return info, ok
+}
+
+func (m *mockTypesChecker) ExpressionType(pkgPath, funcName, expr string, line int) (*core.TypeInfo, bool) {
+	info, ok := m.exprTypes[pkgPath+"."+funcName+"."+expr]
+	return info, ok
+}
+
+func (m *mockTypesChecker) MethodSet(pkgPath, typeName string) ([]string, bool) {
+	methods, ok := m.methodSets[pkgPath+"."+typeName]
+	return methods, ok
+}
+
+func (m *mockTypesChecker) Interfaces(pkgPath string) map[string][]string {
+	return m.interfaces[pkgPath]
+}
+
 // ===== Engine Creation Tests =====
 
 func TestGoTypeInferenceEngine_NewEngine(t *testing.T) {
@@ -143,6 +172,62 @@ func TestGoTypeInferenceEngine_AddGetReturnType(t *testing.T) {
 	assert.Equal(t, "declaration", retrieved.Source)
 }
 
+func TestGoTypeInferenceEngine_AddGetReturnTypes_LocalTuple(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(nil)
+
+	engine.AddReturnTypes("myapp.GetUser", []*core.TypeInfo{
+		{TypeFQN: "myapp.User"},
+		{TypeFQN: "builtin.error"},
+	})
+
+	types, ok := engine.GetReturnTypes("myapp.GetUser")
+	require.True(t, ok)
+	require.Len(t, types, 2)
+	assert.Equal(t, "myapp.User", types[0].TypeFQN)
+	assert.Equal(t, "builtin.error", types[1].TypeFQN)
+}
+
+func TestGoTypeInferenceEngine_AddReturnTypes_IgnoresSingleValueSlice(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(nil)
+
+	engine.AddReturnTypes("myapp.GetUser", []*core.TypeInfo{{TypeFQN: "myapp.User"}})
+
+	_, ok := engine.GetReturnTypes("myapp.GetUser")
+	assert.False(t, ok)
+}
+
+func TestGetReturnTypes_StdlibFallback_ErrorPositionLeftNil(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		StdlibLoader: &mockGoTypesStdlibLoader{
+			packages: map[string]bool{"net/http": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"net/http.Get": {
+					Name: "Get",
+					Returns: []*core.GoReturnValue{
+						{Type: "*Response"},
+						{Type: "error"},
+					},
+				},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	types, ok := engine.GetReturnTypes("net/http.Get")
+	require.True(t, ok)
+	require.Len(t, types, 2)
+	assert.Equal(t, "net/http.Response", types[0].TypeFQN)
+	assert.Nil(t, types[1])
+}
+
+func TestGetReturnTypes_NotFound(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(nil)
+
+	types, ok := engine.GetReturnTypes("nonexistent.Func")
+	assert.False(t, ok)
+	assert.Nil(t, types)
+}
+
 func TestGoTypeInferenceEngine_GetReturnType_NotFound(t *testing.T) {
 	engine := NewGoTypeInferenceEngine(nil)
 
@@ -554,6 +639,236 @@ func TestGetReturnType_LocalTakesPriorityOverStdlib(t *testing.T) {
 	assert.Equal(t, "declaration", info.Source)
 }
 
+// =============================================================================
+// GetReturnType / GetExpressionType — go/types fallback
+// =============================================================================
+
+func TestGetReturnType_GoTypesFallback_UsedWhenNoLocalBinding(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		TypesChecker: &mockTypesChecker{
+			returnTypes: map[string]*core.TypeInfo{
+				"myapp/users.GetUser": {TypeFQN: "myapp/users.User", Confidence: 1.0, Source: "go/types"},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	info, ok := engine.GetReturnType("myapp/users.GetUser")
+
+	assert.True(t, ok)
+	assert.Equal(t, "myapp/users.User", info.TypeFQN)
+	assert.Equal(t, "go/types", info.Source)
+}
+
+func TestGetReturnType_GoTypesFallback_RankedAboveStdlib(t *testing.T) {
+	// Same FQN answerable by both go/types and the stdlib loader — go/types wins.
+	reg := &core.GoModuleRegistry{
+		TypesChecker: &mockTypesChecker{
+			returnTypes: map[string]*core.TypeInfo{
+				"fmt.Sprintf": {TypeFQN: "builtin.string", Confidence: 1.0, Source: "go/types"},
+			},
+		},
+		StdlibLoader: &mockGoTypesStdlibLoader{
+			packages: map[string]bool{"fmt": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"fmt.Sprintf": {Name: "Sprintf", Returns: []*core.GoReturnValue{{Type: "string"}}},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	info, ok := engine.GetReturnType("fmt.Sprintf")
+
+	assert.True(t, ok)
+	assert.Equal(t, "go/types", info.Source)
+}
+
+func TestGetReturnType_GoTypesFallback_FallsThroughToStdlibWhenUnanswered(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		TypesChecker: &mockTypesChecker{returnTypes: map[string]*core.TypeInfo{}},
+		StdlibLoader: &mockGoTypesStdlibLoader{
+			packages: map[string]bool{"fmt": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"fmt.Sprintf": {Name: "Sprintf", Returns: []*core.GoReturnValue{{Type: "string"}}},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	info, ok := engine.GetReturnType("fmt.Sprintf")
+
+	require.True(t, ok)
+	assert.Equal(t, "stdlib", info.Source)
+}
+
+func TestGetReturnType_NilTypesChecker_DoesNotPanic(t *testing.T) {
+	reg := &core.GoModuleRegistry{}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	info, ok := engine.GetReturnType("myapp.Func")
+
+	assert.False(t, ok)
+	assert.Nil(t, info)
+}
+
+func TestGetExpressionType_DelegatesToTypesChecker(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		TypesChecker: &mockTypesChecker{
+			exprTypes: map[string]*core.TypeInfo{
+				"myapp/config.Process.cfg.Timeout": {TypeFQN: "builtin.int", Confidence: 1.0, Source: "go/types"},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	info, ok := engine.GetExpressionType("myapp/config.Process", "cfg.Timeout", 9)
+
+	require.True(t, ok)
+	assert.Equal(t, "builtin.int", info.TypeFQN)
+}
+
+func TestGetExpressionType_NoTypesChecker_ReturnsFalse(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(&core.GoModuleRegistry{})
+
+	info, ok := engine.GetExpressionType("myapp.Func", "x", 1)
+
+	assert.False(t, ok)
+	assert.Nil(t, info)
+}
+
+func TestGetExpressionType_MalformedFQN_ReturnsFalse(t *testing.T) {
+	reg := &core.GoModuleRegistry{TypesChecker: &mockTypesChecker{}}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	info, ok := engine.GetExpressionType("nodot", "x", 1)
+
+	assert.False(t, ok)
+	assert.Nil(t, info)
+}
+
+// =============================================================================
+// Method set / interface satisfaction tracking
+// =============================================================================
+
+func TestAddReturnType_IndexesTypeAgainstKnownInterfaces(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		TypesChecker: &mockTypesChecker{
+			methodSets: map[string][]string{
+				"myapp/writer.Buffer": {"Close", "Write"},
+			},
+			interfaces: map[string]map[string][]string{
+				"myapp/writer": {"myapp/writer.Writer": {"Write"}},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	engine.AddReturnType("myapp/writer.NewBuffer", &core.TypeInfo{TypeFQN: "myapp/writer.Buffer"})
+
+	assert.Equal(t, []string{"myapp/writer.Writer"}, engine.InterfacesFor("myapp/writer.Buffer"))
+	assert.Equal(t, []string{"myapp/writer.Buffer"}, engine.ImplementorsOf("myapp/writer.Writer"))
+}
+
+func TestAddScope_IndexesVariableBindingTypes(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		TypesChecker: &mockTypesChecker{
+			methodSets: map[string][]string{
+				"myapp/writer.Buffer": {"Write"},
+			},
+			interfaces: map[string]map[string][]string{
+				"myapp/writer": {"myapp/writer.Writer": {"Write"}},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	scope := NewGoFunctionScope("myapp.Handle")
+	scope.AddVariable(&GoVariableBinding{
+		VarName: "buf",
+		Type:    &core.TypeInfo{TypeFQN: "myapp/writer.Buffer"},
+	})
+	engine.AddScope(scope)
+
+	assert.Equal(t, []string{"myapp/writer.Writer"}, engine.InterfacesFor("myapp/writer.Buffer"))
+}
+
+func TestIndexTypeIfNew_MethodSetMissingRequiredMethod_NotAnImplementor(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		TypesChecker: &mockTypesChecker{
+			methodSets: map[string][]string{
+				"myapp/writer.ReadOnly": {"Read"},
+			},
+			interfaces: map[string]map[string][]string{
+				"myapp/writer": {"myapp/writer.Writer": {"Write"}},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	engine.AddReturnType("myapp/writer.NewReadOnly", &core.TypeInfo{TypeFQN: "myapp/writer.ReadOnly"})
+
+	assert.Empty(t, engine.InterfacesFor("myapp/writer.ReadOnly"))
+	assert.Empty(t, engine.ImplementorsOf("myapp/writer.Writer"))
+}
+
+func TestIndexTypeIfNew_InterfaceDiscoveredLaterPicksUpEarlierType(t *testing.T) {
+	reg := &core.GoModuleRegistry{
+		TypesChecker: &mockTypesChecker{
+			methodSets: map[string][]string{
+				"myapp/a.Buffer": {"Write"},
+			},
+			interfaces: map[string]map[string][]string{
+				"myapp/a": {},
+				"myapp/b": {"myapp/b.Writer": {"Write"}},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	// Buffer (package a) is indexed before Writer (package b) is ever seen.
+	engine.AddReturnType("myapp/a.NewBuffer", &core.TypeInfo{TypeFQN: "myapp/a.Buffer"})
+	assert.Empty(t, engine.InterfacesFor("myapp/a.Buffer"))
+
+	// Indexing a type from package b folds in myapp/b.Writer and re-checks
+	// every type already indexed, including Buffer from package a.
+	engine.Registry.TypesChecker.(*mockTypesChecker).methodSets["myapp/b.Unrelated"] = nil
+	engine.AddReturnType("myapp/b.NewUnrelated", &core.TypeInfo{TypeFQN: "myapp/b.Unrelated"})
+
+	assert.Equal(t, []string{"myapp/b.Writer"}, engine.InterfacesFor("myapp/a.Buffer"))
+}
+
+func TestIndexTypeIfNew_BuiltinTypeSkipped(t *testing.T) {
+	reg := &core.GoModuleRegistry{TypesChecker: &mockTypesChecker{}}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	engine.AddReturnType("fmt.Sprintf", &core.TypeInfo{TypeFQN: "builtin.string"})
+
+	assert.Empty(t, engine.InterfacesFor("builtin.string"))
+}
+
+func TestIndexTypeIfNew_NoTypesChecker_DoesNotPanic(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(&core.GoModuleRegistry{})
+
+	assert.NotPanics(t, func() {
+		engine.AddReturnType("myapp.NewThing", &core.TypeInfo{TypeFQN: "myapp.Thing"})
+	})
+	assert.Empty(t, engine.InterfacesFor("myapp.Thing"))
+}
+
+func TestInterfacesFor_UnknownType_ReturnsEmptyNotNil(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(&core.GoModuleRegistry{})
+	assert.Empty(t, engine.InterfacesFor("myapp.Unknown"))
+}
+
+func TestImplementorsOf_UnknownInterface_ReturnsEmptyNotNil(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(&core.GoModuleRegistry{})
+	assert.Empty(t, engine.ImplementorsOf("myapp.Unknown"))
+}
+
+func TestMethodSetSatisfies_EmptyInterfaceNeverSatisfied(t *testing.T) {
+	assert.False(t, methodSetSatisfies([]string{"Read", "Write"}, nil))
+}
+
 // =============================================================================
 // stdlibNormalizeType
 // =============================================================================
@@ -632,6 +947,154 @@ func TestGetReturnType_StdlibFallback_EmptyTypeFQNSkipped(t *testing.T) {
 	assert.Equal(t, "os.File", info.TypeFQN)
 }
 
+// =============================================================================
+// Generic instantiation (RecordCallArgs / GetReturnTypeWithArgs)
+// =============================================================================
+
+func TestGetReturnType_GenericInstantiation_SlicesMapUnifiesTypeParams(t *testing.T) {
+	// slices.Map[T, U](s []T, f func(T) U) []U called with []int and func(int) string
+	// should resolve U to builtin.string via unification, not a declared literal type.
+	reg := &core.GoModuleRegistry{
+		StdlibLoader: &mockGoTypesStdlibLoader{
+			packages: map[string]bool{"slices": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"slices.Map": {
+					Name:       "Map",
+					TypeParams: []core.GoTypeParam{{Name: "T"}, {Name: "U"}},
+					Params:     []string{"[]T", "U"},
+					Returns:    []*core.GoReturnValue{{Type: "[]U"}},
+				},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	engine.RecordCallArgs("slices.Map", []*core.TypeInfo{
+		{TypeFQN: "builtin.int"},
+		{TypeFQN: "builtin.string"},
+	})
+
+	info, ok := engine.GetReturnType("slices.Map")
+
+	require.True(t, ok)
+	assert.Equal(t, "builtin.string", info.TypeFQN)
+	assert.Equal(t, float32(1.0), info.Confidence)
+	assert.Equal(t, "generic", info.Source)
+}
+
+func TestGetReturnType_GenericInstantiation_NonGenericReturnFallsBackToNormalize(t *testing.T) {
+	// sync.Pool.Get-style: a function with type parameters declared but whose
+	// return type ("any") doesn't reference any of them — resolved via
+	// stdlibNormalizeType instead, at lower confidence.
+	reg := &core.GoModuleRegistry{
+		StdlibLoader: &mockGoTypesStdlibLoader{
+			packages: map[string]bool{"sync.Pool": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"sync.Pool.Get": {
+					Name:    "Get",
+					Returns: []*core.GoReturnValue{{Type: "any"}},
+				},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	engine.RecordCallArgs("sync.Pool.Get", []*core.TypeInfo{})
+
+	info, ok := engine.GetReturnType("sync.Pool.Get")
+
+	require.True(t, ok)
+	assert.Equal(t, "builtin.any", info.TypeFQN)
+	assert.Equal(t, float32(0.5), info.Confidence)
+	assert.Equal(t, "generic", info.Source)
+}
+
+func TestGetReturnTypeWithArgs_ConflictingBindings_ReturnsFalse(t *testing.T) {
+	// Same type parameter T bound to two different argument types across the
+	// parameter list is a genuine conflict, not a partial match.
+	reg := &core.GoModuleRegistry{
+		StdlibLoader: &mockGoTypesStdlibLoader{
+			packages: map[string]bool{"myslices": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"myslices.Pair": {
+					Name:       "Pair",
+					TypeParams: []core.GoTypeParam{{Name: "T"}},
+					Params:     []string{"T", "T"},
+					Returns:    []*core.GoReturnValue{{Type: "T"}},
+				},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	info, ok := engine.GetReturnTypeWithArgs("myslices.Pair", []*core.TypeInfo{
+		{TypeFQN: "builtin.int"},
+		{TypeFQN: "builtin.string"},
+	})
+
+	assert.False(t, ok)
+	assert.Nil(t, info)
+}
+
+func TestGetReturnType_GenericInstantiation_NoRecordedArgsFallsThroughToStdlib(t *testing.T) {
+	// No RecordCallArgs call for this FQN — stage 3 is a no-op and stage 4
+	// (plain stdlib fallback) still answers as before.
+	reg := &core.GoModuleRegistry{
+		StdlibLoader: &mockGoTypesStdlibLoader{
+			packages: map[string]bool{"fmt": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"fmt.Sprintf": {Name: "Sprintf", Returns: []*core.GoReturnValue{{Type: "string"}}},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	info, ok := engine.GetReturnType("fmt.Sprintf")
+
+	require.True(t, ok)
+	assert.Equal(t, "stdlib", info.Source)
+}
+
+func TestGetReturnType_GenericInstantiation_UnresolvedTypeParamSkipsStdlibFallback(t *testing.T) {
+	// No RecordCallArgs recorded for this FQN, and the function's sole return
+	// is its own unbound type parameter "E" — stage 4 must not fall back to
+	// qualifying "E" as "builtin.E" or "slices.E".
+	reg := &core.GoModuleRegistry{
+		StdlibLoader: &mockGoTypesStdlibLoader{
+			packages: map[string]bool{"slices": true},
+			functions: map[string]*core.GoStdlibFunction{
+				"slices.Max": {
+					Name:       "Max",
+					TypeParams: []core.GoTypeParam{{Name: "E"}},
+					Returns:    []*core.GoReturnValue{{Type: "E"}},
+				},
+			},
+		},
+	}
+	engine := NewGoTypeInferenceEngine(reg)
+
+	_, ok := engine.GetReturnType("slices.Max")
+
+	assert.False(t, ok)
+}
+
+func TestRecordCallArgs_EmptyFQN_Ignored(t *testing.T) {
+	engine := NewGoTypeInferenceEngine(&core.GoModuleRegistry{})
+
+	assert.NotPanics(t, func() {
+		engine.RecordCallArgs("", []*core.TypeInfo{{TypeFQN: "builtin.int"}})
+	})
+}
+
+func TestTypeParamName_StripsPointerAndSliceContainers(t *testing.T) {
+	typeParams := []core.GoTypeParam{{Name: "T"}}
+
+	assert.Equal(t, "T", typeParamName("T", typeParams))
+	assert.Equal(t, "T", typeParamName("*T", typeParams))
+	assert.Equal(t, "T", typeParamName("[]T", typeParams))
+	assert.Equal(t, "", typeParamName("U", typeParams))
+}
+
 func TestStdlibNormalizeType_AllNumericBuiltins(t *testing.T) {
 	for _, typ := range []string{
 		"int8", "int16", "int32", "int64",
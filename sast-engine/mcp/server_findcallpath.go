@@ -0,0 +1,306 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultFindCallPathMaxDepth bounds how many hops the bidirectional search
+// expands on each side before giving up, keeping a query against a large
+// call graph with no path between `from` and `to` from running forever.
+const defaultFindCallPathMaxDepth = 8
+
+// defaultFindCallPathMaxPaths is how many shortest paths are returned when
+// the caller doesn't specify max_paths — the single shortest path is almost
+// always what's wanted; more are only worth the extra payload when asked for.
+const defaultFindCallPathMaxPaths = 1
+
+// maxFindCallPathMaxPaths caps how many paths a caller can request via
+// max_paths. Unlike max_depth — which is itself bounded by the size of the
+// call graph being walked — collectCallPathChain's recursive path collection
+// grows with the fan-in/fan-out of the nodes on the way to the meeting point,
+// so a caller-supplied max_paths with no ceiling lets a large value force
+// combinatorially many paths to be enumerated on a real-world call graph.
+const maxFindCallPathMaxPaths = 50
+
+// toolFindCallPath answers "is `to` reachable from `from`, and how": a
+// bidirectional BFS over callGraph.Edges/ReverseEdges that returns the
+// shortest call path(s) between the two functions, each hop annotated with
+// the call_graph.CallSite metadata (file, line, is_stdlib/stdlib_info or
+// module_info) get_call_details already surfaces for a single edge.
+//
+// Arguments: "from" and "to" (function names or FQNs, resolved the same way
+// as toolGetCallees/toolGetCallers), optional "max_depth" (default 8) and
+// "max_paths" (default 1, capped at maxFindCallPathMaxPaths).
+//
+// Wraps findCallPath with observeToolCall so find_call_path invocations are
+// captured by the server's configured MetricsSink/log level (see
+// server_observability.go). toolGetCalleesPage and toolGetCallersPage are
+// wrapped the same way; handleToolsCall's other tools are not.
+func (s *Server) toolFindCallPath(args map[string]interface{}) (string, bool) {
+	start := time.Now()
+	result, isError := s.findCallPath(args)
+
+	errClass := ""
+	if isError {
+		errClass = "invalid_request"
+	}
+	s.observeToolCall("find_call_path", args, start, len(result), strings.Contains(result, `"stdlib_info"`), errClass)
+
+	return result, isError
+}
+
+// findCallPath is toolFindCallPath's unwrapped implementation.
+func (s *Server) findCallPath(args map[string]interface{}) (string, bool) {
+	fromArg, _ := args["from"].(string)
+	toArg, _ := args["to"].(string)
+	if strings.TrimSpace(fromArg) == "" || strings.TrimSpace(toArg) == "" {
+		return toolFindCallPathError("find_call_path requires both 'from' and 'to' arguments")
+	}
+
+	fromFQN, ok := s.resolveFunctionArg(fromArg)
+	if !ok {
+		return toolFindCallPathError(fmt.Sprintf("function not found: %s", fromArg))
+	}
+	toFQN, ok := s.resolveFunctionArg(toArg)
+	if !ok {
+		return toolFindCallPathError(fmt.Sprintf("function not found: %s", toArg))
+	}
+
+	maxDepth := defaultFindCallPathMaxDepth
+	if v, ok := args["max_depth"].(float64); ok && v > 0 {
+		maxDepth = int(v)
+	}
+	maxPaths := defaultFindCallPathMaxPaths
+	if v, ok := args["max_paths"].(float64); ok && v > 0 {
+		maxPaths = int(v)
+		if maxPaths > maxFindCallPathMaxPaths {
+			maxPaths = maxFindCallPathMaxPaths
+		}
+	}
+
+	nodePaths := s.bidirectionalCallPaths(fromFQN, toFQN, maxDepth, maxPaths)
+
+	paths := make([]map[string]interface{}, 0, len(nodePaths))
+	for _, nodes := range nodePaths {
+		paths = append(paths, s.describeCallPath(nodes))
+	}
+
+	result := map[string]interface{}{
+		"from":  fromFQN,
+		"to":    toFQN,
+		"paths": paths,
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return toolFindCallPathError(fmt.Sprintf("failed to marshal call path result: %v", err))
+	}
+	return string(data), false
+}
+
+// toolFindCallPathError formats a find_call_path-specific tool error.
+func toolFindCallPathError(message string) (string, bool) {
+	return message, true
+}
+
+// resolveFunctionArg resolves a function name argument to its FQN key
+// in callGraph.Functions: an exact match first, falling back to a unique
+// suffix match (".<name>") the way a caller who only knows a short function
+// name, not its full qualification, would expect.
+func (s *Server) resolveFunctionArg(name string) (string, bool) {
+	if s.callGraph == nil {
+		return "", false
+	}
+	if _, ok := s.callGraph.Functions[name]; ok {
+		return name, true
+	}
+	// A callee outside the analysed code (e.g. a stdlib function) has no
+	// Functions entry; its exact FQN is accepted when it is an edge target.
+	for _, callees := range s.callGraph.Edges {
+		for _, callee := range callees {
+			if callee == name {
+				return name, true
+			}
+		}
+	}
+
+	suffix := "." + name
+	match := ""
+	for fqn := range s.callGraph.Functions {
+		if strings.HasSuffix(fqn, suffix) {
+			if match != "" {
+				return "", false // ambiguous — more than one function named this
+			}
+			match = fqn
+		}
+	}
+	return match, match != ""
+}
+
+// bidirectionalCallPaths runs alternating forward/backward BFS layers from
+// from and to respectively — forward along callGraph.Edges, backward along
+// callGraph.ReverseEdges — until a node visited by both sides is found (the
+// two searches "meet"), or maxDepth total layers are expanded without one.
+// Returns up to maxPaths distinct shortest node sequences from from to to.
+func (s *Server) bidirectionalCallPaths(from, to string, maxDepth, maxPaths int) [][]string {
+	if from == to {
+		return [][]string{{from}}
+	}
+	if s.callGraph == nil {
+		return nil
+	}
+
+	fwdDepth := map[string]int{from: 0}
+	fwdPred := map[string][]string{}
+	fwdFrontier := []string{from}
+
+	bwdDepth := map[string]int{to: 0}
+	bwdSucc := map[string][]string{}
+	bwdFrontier := []string{to}
+
+	meet := ""
+	depth := 0
+	var layer []string           // the layer expanded last
+	var otherSide map[string]int // the opposite search's visited set for it
+	for depth < maxDepth && meet == "" && len(fwdFrontier) > 0 && len(bwdFrontier) > 0 {
+		depth++
+		fwdFrontier, meet = expandCallPathLayer(fwdFrontier, depth, s.callGraph.Edges, fwdDepth, fwdPred, bwdDepth)
+		layer, otherSide = fwdFrontier, bwdDepth
+		if meet != "" || depth >= maxDepth {
+			break
+		}
+		depth++
+		bwdFrontier, meet = expandCallPathLayer(bwdFrontier, depth, s.callGraph.ReverseEdges, bwdDepth, bwdSucc, fwdDepth)
+		layer, otherSide = bwdFrontier, fwdDepth
+	}
+	if meet == "" {
+		return nil
+	}
+
+	// Every node of the meeting layer that the other side also reached lies
+	// on a shortest path, so paths are collected through each of them.
+	var paths [][]string
+	for _, node := range layer {
+		if _, ok := otherSide[node]; !ok {
+			continue
+		}
+		prefixes := collectCallPathChain(node, from, fwdPred, maxPaths)
+		suffixes := collectCallPathChain(node, to, bwdSucc, maxPaths)
+		for _, prefix := range prefixes {
+			reversed := reverseStrings(prefix)
+			for _, suffix := range suffixes {
+				paths = append(paths, append(reversed, suffix[1:]...))
+				if len(paths) >= maxPaths {
+					return paths
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// expandCallPathLayer advances frontier by one BFS layer over adjacency,
+// recording each newly reached node's depth and the frontier node(s) it was
+// reached from in chain. If a node reached this layer is already present in
+// otherDepth (the opposite search's visited set), that node is returned as
+// the meeting point.
+func expandCallPathLayer(
+	frontier []string,
+	depth int,
+	adjacency map[string][]string,
+	visitedDepth map[string]int,
+	chain map[string][]string,
+	otherDepth map[string]int,
+) ([]string, string) {
+	seenThisLayer := map[string]bool{}
+	var next []string
+	meet := ""
+	for _, node := range frontier {
+		for _, neighbor := range adjacency[node] {
+			if existing, known := visitedDepth[neighbor]; known && existing < depth {
+				continue // already reached via a shorter path
+			}
+			visitedDepth[neighbor] = depth
+			chain[neighbor] = append(chain[neighbor], node)
+			if !seenThisLayer[neighbor] {
+				seenThisLayer[neighbor] = true
+				next = append(next, neighbor)
+			}
+			if _, ok := otherDepth[neighbor]; ok && meet == "" {
+				meet = neighbor
+			}
+		}
+	}
+	return next, meet
+}
+
+// collectCallPathChain walks chain (a map from node to the node(s) it was
+// reached from during BFS) from start back to end, returning every distinct
+// [start, ..., end] sequence it traces out, capped at limit.
+func collectCallPathChain(start, end string, chain map[string][]string, limit int) [][]string {
+	if start == end {
+		return [][]string{{end}}
+	}
+	var out [][]string
+	for _, prev := range chain[start] {
+		for _, tail := range collectCallPathChain(prev, end, chain, limit) {
+			out = append(out, append([]string{start}, tail...))
+			if len(out) >= limit {
+				return out
+			}
+		}
+		if len(out) >= limit {
+			return out
+		}
+	}
+	return out
+}
+
+// reverseStrings returns a new slice with s's elements in reverse order.
+func reverseStrings(s []string) []string {
+	out := make([]string, len(s))
+	for i, v := range s {
+		out[len(s)-1-i] = v
+	}
+	return out
+}
+
+// describeCallPath builds the JSON-ready description of one node sequence:
+// its length and, for each consecutive pair, the hop's call-site metadata
+// (file, line, is_stdlib, and stdlib_info/module_info when available).
+func (s *Server) describeCallPath(nodes []string) map[string]interface{} {
+	hops := make([]map[string]interface{}, 0, len(nodes)-1)
+	for i := 0; i+1 < len(nodes); i++ {
+		hops = append(hops, s.describeCallPathHop(nodes[i], nodes[i+1]))
+	}
+	return map[string]interface{}{
+		"length":    len(nodes),
+		"functions": nodes,
+		"hops":      hops,
+	}
+}
+
+// describeCallPathHop finds callerFQN's call site targeting calleeFQN (if
+// recorded) and renders its location and stdlib/module enrichment.
+func (s *Server) describeCallPathHop(callerFQN, calleeFQN string) map[string]interface{} {
+	hop := map[string]interface{}{"from": callerFQN, "to": calleeFQN}
+	if s.callGraph == nil {
+		return hop
+	}
+
+	for _, cs := range s.callGraph.CallSites[callerFQN] {
+		if cs.TargetFQN != calleeFQN {
+			continue
+		}
+		hop["file"] = cs.Location.File
+		hop["line"] = cs.Location.Line
+		hop["is_stdlib"] = cs.IsStdlib
+		if key, info := s.externalInfoForFQN(calleeFQN, cs.IsStdlib); key != "" {
+			hop[key] = info
+		}
+		break
+	}
+	return hop
+}
@@ -0,0 +1,292 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph"
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createChainGoTestServer builds a Server over a simple linear call chain
+// A -> B -> C -> D, for reachability tests.
+func createChainGoTestServer() *Server {
+	callGraph := core.NewCallGraph()
+	chain := []string{"myapp.a.A", "myapp.b.B", "myapp.c.C", "myapp.d.D"}
+	modules := map[string]string{}
+	fileToModule := map[string]string{}
+
+	for i, fqn := range chain {
+		file := "/proj/" + string(rune('a'+i)) + ".go"
+		callGraph.Functions[fqn] = &graph.Node{ID: fqn, Type: "function_declaration", Name: fqn, File: file, LineNumber: 1}
+		modules[fqn[:len(fqn)-2]] = file
+		fileToModule[file] = fqn[:len(fqn)-2]
+	}
+
+	for i := 0; i+1 < len(chain); i++ {
+		caller, callee := chain[i], chain[i+1]
+		callGraph.Edges[caller] = []string{callee}
+		callGraph.ReverseEdges[callee] = []string{caller}
+		callGraph.CallSites[caller] = []core.CallSite{
+			{
+				Target:    callee,
+				TargetFQN: callee,
+				Location:  core.Location{File: modules[caller[:len(caller)-2]], Line: 10 + i},
+				Resolved:  true,
+				IsStdlib:  false,
+			},
+		}
+	}
+
+	moduleRegistry := &core.ModuleRegistry{
+		Modules:      modules,
+		FileToModule: fileToModule,
+		ShortNames:   map[string][]string{},
+	}
+
+	return NewServer("/proj", "", callGraph, moduleRegistry, nil, time.Second, false)
+}
+
+// createLayeredFanGoTestServer builds a Server over a layered call graph with
+// a single start node S, a single end node E, and `layers` middle layers of
+// two nodes each, fully bipartite-connected to the next layer — so there are
+// 2^layers distinct shortest S -> E paths, enough to exercise max_paths
+// without the pathological graph sizes a real-world fan-in/fan-out case
+// would need.
+func createLayeredFanGoTestServer(layers int) *Server {
+	callGraph := core.NewCallGraph()
+	modules := map[string]string{}
+	fileToModule := map[string]string{}
+
+	addFunc := func(fqn, file string) {
+		callGraph.Functions[fqn] = &graph.Node{ID: fqn, Type: "function_declaration", Name: fqn, File: file, LineNumber: 1}
+		module := fqn[:strings.LastIndex(fqn, ".")]
+		modules[module] = file
+		fileToModule[file] = module
+	}
+	addEdge := func(caller, callee, file string, line int) {
+		callGraph.Edges[caller] = append(callGraph.Edges[caller], callee)
+		callGraph.ReverseEdges[callee] = append(callGraph.ReverseEdges[callee], caller)
+		callGraph.CallSites[caller] = append(callGraph.CallSites[caller], core.CallSite{
+			Target:    callee,
+			TargetFQN: callee,
+			Location:  core.Location{File: file, Line: line},
+			Resolved:  true,
+			IsStdlib:  false,
+		})
+	}
+
+	layerNodes := func(layer int) []string {
+		return []string{
+			fmt.Sprintf("myapp.fan.L%dN0", layer),
+			fmt.Sprintf("myapp.fan.L%dN1", layer),
+		}
+	}
+
+	addFunc("myapp.fan.S", "/proj/s.go")
+	prev := []string{"myapp.fan.S"}
+	for l := 0; l < layers; l++ {
+		nodes := layerNodes(l)
+		for _, n := range nodes {
+			addFunc(n, "/proj/"+n+".go")
+		}
+		for _, from := range prev {
+			for _, to := range nodes {
+				addEdge(from, to, "/proj/"+from+".go", 1)
+			}
+		}
+		prev = nodes
+	}
+	addFunc("myapp.fan.E", "/proj/e.go")
+	for _, from := range prev {
+		addEdge(from, "myapp.fan.E", "/proj/"+from+".go", 1)
+	}
+
+	moduleRegistry := &core.ModuleRegistry{
+		Modules:      modules,
+		FileToModule: fileToModule,
+		ShortNames:   map[string][]string{},
+	}
+
+	return NewServer("/proj", "", callGraph, moduleRegistry, nil, time.Second, false)
+}
+
+func TestToolFindCallPath_DirectChain(t *testing.T) {
+	server := createChainGoTestServer()
+
+	resultStr, isError := server.toolFindCallPath(map[string]interface{}{
+		"from": "myapp.a.A",
+		"to":   "myapp.d.D",
+	})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+
+	paths, ok := result["paths"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, paths, 1)
+
+	path := paths[0].(map[string]interface{})
+	assert.Equal(t, float64(4), path["length"])
+	functions, ok := path["functions"].([]interface{})
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{"myapp.a.A", "myapp.b.B", "myapp.c.C", "myapp.d.D"}, functions)
+
+	hops, ok := path["hops"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, hops, 3)
+	firstHop := hops[0].(map[string]interface{})
+	assert.Equal(t, "myapp.a.A", firstHop["from"])
+	assert.Equal(t, "myapp.b.B", firstHop["to"])
+	assert.Equal(t, false, firstHop["is_stdlib"])
+}
+
+func TestToolFindCallPath_SameFunction(t *testing.T) {
+	server := createChainGoTestServer()
+
+	resultStr, isError := server.toolFindCallPath(map[string]interface{}{
+		"from": "myapp.a.A",
+		"to":   "myapp.a.A",
+	})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+	paths := result["paths"].([]interface{})
+	require.Len(t, paths, 1)
+	assert.Equal(t, float64(1), paths[0].(map[string]interface{})["length"])
+}
+
+func TestToolFindCallPath_NoPath(t *testing.T) {
+	server := createChainGoTestServer()
+	// D never calls back to A, so there is no path from D to A.
+	resultStr, isError := server.toolFindCallPath(map[string]interface{}{
+		"from": "myapp.d.D",
+		"to":   "myapp.a.A",
+	})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+	paths := result["paths"].([]interface{})
+	assert.Empty(t, paths)
+}
+
+func TestToolFindCallPath_UnknownFunction(t *testing.T) {
+	server := createChainGoTestServer()
+	_, isError := server.toolFindCallPath(map[string]interface{}{
+		"from": "myapp.a.A",
+		"to":   "does.not.Exist",
+	})
+	assert.True(t, isError)
+}
+
+func TestToolFindCallPath_MissingArguments(t *testing.T) {
+	server := createChainGoTestServer()
+	_, isError := server.toolFindCallPath(map[string]interface{}{"from": "myapp.a.A"})
+	assert.True(t, isError)
+}
+
+func TestToolFindCallPath_MaxDepthBoundsSearch(t *testing.T) {
+	server := createChainGoTestServer()
+	// A -> D is 3 hops; a max_depth of 1 cannot reach it.
+	resultStr, isError := server.toolFindCallPath(map[string]interface{}{
+		"from":      "myapp.a.A",
+		"to":        "myapp.d.D",
+		"max_depth": float64(1),
+	})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+	assert.Empty(t, result["paths"].([]interface{}))
+}
+
+func TestToolFindCallPath_MaxPathsReturnsRequestedCount(t *testing.T) {
+	server := createLayeredFanGoTestServer(3) // 2^3 = 8 distinct S -> E paths
+
+	resultStr, isError := server.toolFindCallPath(map[string]interface{}{
+		"from":      "myapp.fan.S",
+		"to":        "myapp.fan.E",
+		"max_paths": float64(5),
+	})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+	paths := result["paths"].([]interface{})
+	assert.Len(t, paths, 5)
+}
+
+func TestToolFindCallPath_MaxPathsClampedToCeiling(t *testing.T) {
+	// 2^6 = 64 distinct S -> E paths — more than maxFindCallPathMaxPaths, so a
+	// caller asking for far more than that must still get back no more than
+	// the ceiling, not all 64.
+	server := createLayeredFanGoTestServer(6)
+
+	resultStr, isError := server.toolFindCallPath(map[string]interface{}{
+		"from":      "myapp.fan.S",
+		"to":        "myapp.fan.E",
+		"max_paths": float64(1_000_000),
+	})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+	paths := result["paths"].([]interface{})
+	assert.Len(t, paths, maxFindCallPathMaxPaths)
+}
+
+func TestToolFindCallPath_StdlibInfoOnHop(t *testing.T) {
+	server := createGoTestServer()
+	withStdlibLoader(server, &mockMCPStdlibLoader{
+		stdlibPkgs: map[string]bool{"net/http": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"net/http.Get": {Name: "Get", Signature: "func Get(url string) (resp *Response, err error)"},
+		},
+	})
+
+	resultStr, isError := server.toolFindCallPath(map[string]interface{}{
+		"from": "Handle",
+		"to":   "net/http.Get",
+	})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+	paths := result["paths"].([]interface{})
+	require.Len(t, paths, 1)
+	hops := paths[0].(map[string]interface{})["hops"].([]interface{})
+	require.Len(t, hops, 1)
+	hop := hops[0].(map[string]interface{})
+	assert.Equal(t, true, hop["is_stdlib"])
+	stdlibInfo, ok := hop["stdlib_info"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "net/http", stdlibInfo["package"])
+}
+
+func TestHandleToolsCall_FindCallPath_RoundTrip(t *testing.T) {
+	server := createChainGoTestServer()
+
+	req := &JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params:  json.RawMessage(`{"name":"find_call_path","arguments":{"from":"myapp.a.A","to":"myapp.d.D"}}`),
+	}
+
+	resp := server.handleToolsCall(req)
+	require.NotNil(t, resp)
+
+	toolResult, ok := resp.Result.(ToolResult)
+	require.True(t, ok)
+	assert.False(t, toolResult.IsError)
+	assert.Contains(t, toolResult.Content[0].Text, "myapp.d.D")
+	assert.Contains(t, toolResult.Content[0].Text, "hops")
+}
@@ -0,0 +1,76 @@
+package mcp
+
+import "strings"
+
+// moduleInfoForFQN is the third-party sibling of stdlibInfoForFQN: it
+// resolves a call target's FQN to its dependency's exported metadata
+// (package, version, signature, return_types) via
+// goModuleRegistry.ThirdPartyLoader, so users get the same enrichment for a
+// call into a vendored library (e.g. docker/cli, gambol99/go-marathon) that
+// they already get for a stdlib call like net/http.Get.
+//
+// Returns nil when the registry or loader is unavailable, the FQN has no
+// "importPath.funcName" shape, or importPath isn't a recognised dependency.
+// When the package is recognised but the specific function wasn't indexed,
+// the package/version info is still returned without a "signature" or
+// "return_types" key — mirroring stdlibInfoForFQN's partial-match behaviour.
+func (s *Server) moduleInfoForFQN(fqn string) map[string]interface{} {
+	if s.goModuleRegistry == nil || s.goModuleRegistry.ThirdPartyLoader == nil {
+		return nil
+	}
+
+	idx := strings.LastIndex(fqn, ".")
+	if idx < 0 {
+		return nil
+	}
+	importPath, funcName := fqn[:idx], fqn[idx+1:]
+
+	loader := s.goModuleRegistry.ThirdPartyLoader
+	if !loader.ValidateModuleImport(importPath) {
+		return nil
+	}
+
+	info := map[string]interface{}{"package": importPath}
+	if version, ok := s.goModuleRegistry.ModuleVersions[importPath]; ok && version != "" {
+		info["version"] = version
+	}
+
+	fn, err := loader.GetFunction(importPath, funcName)
+	if err != nil {
+		return info
+	}
+	info["signature"] = fn.Signature
+
+	var returnTypes []string
+	for _, ret := range fn.Returns {
+		if ret.Type != "" {
+			returnTypes = append(returnTypes, ret.Type)
+		}
+	}
+	if len(returnTypes) > 0 {
+		info["return_types"] = returnTypes
+	}
+
+	return info
+}
+
+// externalInfoForFQN picks the right enrichment block for a callee FQN:
+// "stdlib_info" when the call site is flagged as stdlib, "module_info" when
+// it resolves to a third-party dependency, or no block at all (empty key)
+// when neither loader has anything to say. getCalleesPage, getCallersPage,
+// streamCalleesPage and describeCallPathHop call this once per callee/call
+// site to decide which key, if any, to attach alongside is_stdlib;
+// toolGetCallees and toolGetCallDetails do not call it and attach no
+// module_info block.
+func (s *Server) externalInfoForFQN(fqn string, isStdlib bool) (string, map[string]interface{}) {
+	if isStdlib {
+		if info := s.stdlibInfoForFQN(fqn); info != nil {
+			return "stdlib_info", info
+		}
+		return "", nil
+	}
+	if info := s.moduleInfoForFQN(fqn); info != nil {
+		return "module_info", info
+	}
+	return "", nil
+}
@@ -0,0 +1,175 @@
+package mcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errMockModuleNotFound is the sentinel error returned by the third-party
+// mock loader.
+var errMockModuleNotFound = errors.New("not found in mock")
+
+// mockMCPThirdPartyLoader implements core.ThirdPartyLoader for MCP-layer
+// testing, avoiding network access and a real GOMODCACHE.
+type mockMCPThirdPartyLoader struct {
+	packages  map[string]bool
+	functions map[string]*core.GoStdlibFunction // key: "importPath.funcName"
+}
+
+func (m *mockMCPThirdPartyLoader) ValidateModuleImport(importPath string) bool {
+	return m.packages[importPath]
+}
+
+func (m *mockMCPThirdPartyLoader) GetFunction(importPath, funcName string) (*core.GoStdlibFunction, error) {
+	fn, ok := m.functions[importPath+"."+funcName]
+	if !ok {
+		return nil, errMockModuleNotFound
+	}
+	return fn, nil
+}
+
+func (m *mockMCPThirdPartyLoader) GetType(_, _ string) (*core.GoStdlibType, error) {
+	return nil, errMockModuleNotFound
+}
+
+func (m *mockMCPThirdPartyLoader) ModuleCount() int {
+	return len(m.packages)
+}
+
+// withThirdPartyLoader attaches a third-party loader (and optional module
+// versions) to the server's goModuleRegistry, mutating it in place.
+func withThirdPartyLoader(s *Server, loader core.ThirdPartyLoader, versions map[string]string) {
+	reg := core.NewGoModuleRegistry()
+	reg.ThirdPartyLoader = loader
+	reg.ModuleVersions = versions
+	s.goModuleRegistry = reg
+}
+
+func TestModuleInfoForFQN_NilRegistry(t *testing.T) {
+	server := createTestServer()
+	result := server.moduleInfoForFQN("github.com/lib/pq.Open")
+	assert.Nil(t, result)
+}
+
+func TestModuleInfoForFQN_NilLoader(t *testing.T) {
+	server := createTestServer()
+	server.goModuleRegistry = core.NewGoModuleRegistry()
+	result := server.moduleInfoForFQN("github.com/lib/pq.Open")
+	assert.Nil(t, result)
+}
+
+func TestModuleInfoForFQN_NoDotInFQN(t *testing.T) {
+	server := createGoTestServer()
+	withThirdPartyLoader(server, &mockMCPThirdPartyLoader{
+		packages: map[string]bool{"github.com/lib/pq": true},
+	}, nil)
+	result := server.moduleInfoForFQN("noDot")
+	assert.Nil(t, result)
+}
+
+func TestModuleInfoForFQN_NotARecognisedDependency(t *testing.T) {
+	server := createGoTestServer()
+	withThirdPartyLoader(server, &mockMCPThirdPartyLoader{
+		packages: map[string]bool{"github.com/lib/pq": true},
+	}, nil)
+	result := server.moduleInfoForFQN("myapp.util.Helper")
+	assert.Nil(t, result)
+}
+
+func TestModuleInfoForFQN_FunctionNotIndexed(t *testing.T) {
+	server := createGoTestServer()
+	withThirdPartyLoader(server, &mockMCPThirdPartyLoader{
+		packages:  map[string]bool{"github.com/lib/pq": true},
+		functions: map[string]*core.GoStdlibFunction{},
+	}, map[string]string{"github.com/lib/pq": "v1.10.0"})
+
+	result := server.moduleInfoForFQN("github.com/lib/pq.NonExistent")
+	require.NotNil(t, result)
+	assert.Equal(t, "github.com/lib/pq", result["package"])
+	assert.Equal(t, "v1.10.0", result["version"])
+	assert.NotContains(t, result, "signature")
+	assert.NotContains(t, result, "return_types")
+}
+
+func TestModuleInfoForFQN_FunctionFoundWithSignature(t *testing.T) {
+	server := createGoTestServer()
+	withThirdPartyLoader(server, &mockMCPThirdPartyLoader{
+		packages: map[string]bool{"github.com/lib/pq": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"github.com/lib/pq.Open": {
+				Name:      "Open",
+				Signature: "func Open(name string) (*DB, error)",
+				Returns: []*core.GoReturnValue{
+					{Type: "*DB"},
+					{Type: "error"},
+				},
+			},
+		},
+	}, map[string]string{"github.com/lib/pq": "v1.10.0"})
+
+	result := server.moduleInfoForFQN("github.com/lib/pq.Open")
+	require.NotNil(t, result)
+	assert.Equal(t, "github.com/lib/pq", result["package"])
+	assert.Equal(t, "v1.10.0", result["version"])
+	assert.Equal(t, "func Open(name string) (*DB, error)", result["signature"])
+	retTypes, ok := result["return_types"].([]string)
+	require.True(t, ok)
+	assert.Equal(t, []string{"*DB", "error"}, retTypes)
+}
+
+func TestModuleInfoForFQN_NoVersionKnown(t *testing.T) {
+	server := createGoTestServer()
+	withThirdPartyLoader(server, &mockMCPThirdPartyLoader{
+		packages: map[string]bool{"github.com/lib/pq": true},
+	}, nil)
+
+	result := server.moduleInfoForFQN("github.com/lib/pq.Open")
+	require.NotNil(t, result)
+	assert.Equal(t, "github.com/lib/pq", result["package"])
+	assert.NotContains(t, result, "version")
+}
+
+func TestExternalInfoForFQN_StdlibTakesStdlibPath(t *testing.T) {
+	server := createGoTestServer()
+	withStdlibLoader(server, &mockMCPStdlibLoader{
+		stdlibPkgs: map[string]bool{"net/http": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"net/http.Get": {Name: "Get", Signature: "func Get(url string) (resp *Response, err error)"},
+		},
+	})
+
+	key, info := server.externalInfoForFQN("net/http.Get", true)
+	assert.Equal(t, "stdlib_info", key)
+	require.NotNil(t, info)
+	assert.Equal(t, "net/http", info["package"])
+}
+
+func TestExternalInfoForFQN_ThirdPartyWhenNotStdlib(t *testing.T) {
+	server := createGoTestServer()
+	withThirdPartyLoader(server, &mockMCPThirdPartyLoader{
+		packages: map[string]bool{"github.com/lib/pq": true},
+		functions: map[string]*core.GoStdlibFunction{
+			"github.com/lib/pq.Open": {Name: "Open", Signature: "func Open(name string) (*DB, error)"},
+		},
+	}, nil)
+
+	key, info := server.externalInfoForFQN("github.com/lib/pq.Open", false)
+	assert.Equal(t, "module_info", key)
+	require.NotNil(t, info)
+	assert.Equal(t, "github.com/lib/pq", info["package"])
+}
+
+func TestExternalInfoForFQN_LocalCalleeNoBlock(t *testing.T) {
+	server := createGoTestServer()
+	withThirdPartyLoader(server, &mockMCPThirdPartyLoader{
+		packages: map[string]bool{"github.com/lib/pq": true},
+	}, nil)
+
+	key, info := server.externalInfoForFQN("myapp.util.Helper", false)
+	assert.Equal(t, "", key)
+	assert.Nil(t, info)
+}
@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+)
+
+// slowToolCallThreshold is the latency above which a tool call is logged as
+// slow even when the configured log level would otherwise suppress it.
+const slowToolCallThreshold = 2 * time.Second
+
+// LogLevel mirrors the familiar log.Level-typed config pattern: an ordered
+// severity a configured threshold is compared against, so operators can
+// quiet the server's per-call logging in production while still seeing
+// errors and slow queries.
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// String renders the level the way it should appear in a log line or metric
+// label.
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ToolCallMetrics describes a single tool-call observation, independent of
+// whatever backend (if any) a MetricsSink forwards it to.
+type ToolCallMetrics struct {
+	Tool           string
+	ArgKeys        []string
+	Latency        time.Duration
+	ResultSize     int
+	StdlibInfoHit  bool
+	StdlibCacheHit bool
+	ErrorClass     string // "" when the call succeeded
+}
+
+// MetricsSink receives tool-call observations. Implementations decide how to
+// expose them — e.g. as mcp_tool_calls_total{tool,status},
+// mcp_tool_latency_seconds, and mcp_stdlib_lookup_total{hit,miss} — keeping
+// Prometheus/OpenTelemetry client libraries out of the core module; this
+// package only depends on the interface.
+type MetricsSink interface {
+	ObserveToolCall(ToolCallMetrics)
+}
+
+// noopMetricsSink is the MetricsSink SetObservability installs in place of a
+// nil sink: observations are dropped.
+type noopMetricsSink struct{}
+
+func (noopMetricsSink) ObserveToolCall(ToolCallMetrics) {}
+
+// SetObservability attaches a metrics sink, a minimum log level, and the
+// logger structured tool-call logging is written to. A nil sink disables
+// metrics (observations are dropped); a nil logger disables logging
+// entirely. The zero LogLevel (LogLevelDebug) logs every call, matching the
+// server's behaviour before observability was configured.
+func (s *Server) SetObservability(sink MetricsSink, level LogLevel, logger *output.Logger) {
+	if sink == nil {
+		sink = noopMetricsSink{}
+	}
+	s.metricsSink = sink
+	s.logLevel = level
+	s.obsLogger = logger
+}
+
+// observeToolCall records one tool invocation: it forwards a ToolCallMetrics
+// to the configured MetricsSink (if any) and logs a line — at LogLevelError
+// for a failed call, LogLevelWarn for one slower than slowToolCallThreshold,
+// LogLevelDebug otherwise — provided that level meets the server's
+// configured threshold.
+func (s *Server) observeToolCall(tool string, args map[string]interface{}, start time.Time, resultSize int, stdlibInfoHit bool, errClass string) {
+	latency := time.Since(start)
+
+	if s.metricsSink != nil {
+		s.metricsSink.ObserveToolCall(ToolCallMetrics{
+			Tool:          tool,
+			ArgKeys:       sortedArgKeys(args),
+			Latency:       latency,
+			ResultSize:    resultSize,
+			StdlibInfoHit: stdlibInfoHit,
+			ErrorClass:    errClass,
+		})
+	}
+
+	switch {
+	case errClass != "":
+		s.logToolCall(LogLevelError, "tool %s failed after %s: %s", tool, latency, errClass)
+	case latency >= slowToolCallThreshold:
+		s.logToolCall(LogLevelWarn, "tool %s took %s (slow)", tool, latency)
+	default:
+		s.logToolCall(LogLevelDebug, "tool %s completed in %s (%d bytes)", tool, latency, resultSize)
+	}
+}
+
+// logToolCall writes a structured tool-call log line via s.obsLogger, but
+// only when level meets or exceeds s.logLevel — this is the suppression
+// knob operators use to quiet routine per-call logging in production.
+func (s *Server) logToolCall(level LogLevel, format string, args ...interface{}) {
+	if level < s.logLevel || s.obsLogger == nil {
+		return
+	}
+	if level >= LogLevelWarn {
+		s.obsLogger.Warning(format, args...)
+		return
+	}
+	s.obsLogger.Progress(format, args...)
+}
+
+// sortedArgKeys returns args' keys, sorted. Metrics labels capture argument
+// *shape* (which parameters were supplied), never their values — an FQN or
+// file path has no place in a metrics label's cardinality.
+func sortedArgKeys(args map[string]interface{}) []string {
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
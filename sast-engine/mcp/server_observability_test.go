@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMetricsSink records every ToolCallMetrics it receives, for assertions.
+type fakeMetricsSink struct {
+	calls []ToolCallMetrics
+}
+
+func (f *fakeMetricsSink) ObserveToolCall(m ToolCallMetrics) {
+	f.calls = append(f.calls, m)
+}
+
+func TestLogLevel_String(t *testing.T) {
+	assert.Equal(t, "debug", LogLevelDebug.String())
+	assert.Equal(t, "info", LogLevelInfo.String())
+	assert.Equal(t, "warn", LogLevelWarn.String())
+	assert.Equal(t, "error", LogLevelError.String())
+	assert.Equal(t, "unknown", LogLevel(99).String())
+}
+
+func TestSetObservability_NilSinkDisablesMetricsWithoutPanicking(t *testing.T) {
+	server := createChainGoTestServer()
+	server.SetObservability(nil, LogLevelDebug, nil)
+
+	assert.NotPanics(t, func() {
+		server.observeToolCall("find_call_path", nil, time.Now(), 10, false, "")
+	})
+}
+
+func TestObserveToolCall_RecordsSuccessfulCall(t *testing.T) {
+	server := createChainGoTestServer()
+	sink := &fakeMetricsSink{}
+	server.SetObservability(sink, LogLevelDebug, output.NewLogger(output.VerbosityDefault))
+
+	_, isError := server.toolFindCallPath(map[string]interface{}{"from": "myapp.a.A", "to": "myapp.d.D"})
+	require.False(t, isError)
+
+	require.Len(t, sink.calls, 1)
+	call := sink.calls[0]
+	assert.Equal(t, "find_call_path", call.Tool)
+	assert.Equal(t, []string{"from", "to"}, call.ArgKeys)
+	assert.Equal(t, "", call.ErrorClass)
+	assert.Positive(t, call.ResultSize)
+}
+
+func TestObserveToolCall_RecordsErrorClass(t *testing.T) {
+	server := createChainGoTestServer()
+	sink := &fakeMetricsSink{}
+	server.SetObservability(sink, LogLevelDebug, nil)
+
+	_, isError := server.toolFindCallPath(map[string]interface{}{"from": "myapp.a.A"})
+	require.True(t, isError)
+
+	require.Len(t, sink.calls, 1)
+	assert.Equal(t, "invalid_request", sink.calls[0].ErrorClass)
+}
+
+func TestLogToolCall_BelowAndAtConfiguredLevelDoesNotPanic(t *testing.T) {
+	server := createChainGoTestServer()
+	logged := 0
+	server.obsLogger = output.NewLogger(output.VerbosityDefault)
+	server.logLevel = LogLevelError
+
+	// A debug-level line must not reach the logger when the threshold is Error.
+	server.logToolCall(LogLevelDebug, "should be suppressed, call #%d", logged)
+	// No observable side effect to assert on output.Logger directly, but this
+	// must not panic and must respect the early return (covered by not
+	// advancing past the level check in logToolCall).
+	assert.NotPanics(t, func() {
+		server.logToolCall(LogLevelError, "should be logged, call #%d", logged)
+	})
+}
+
+func TestSortedArgKeys(t *testing.T) {
+	assert.Equal(t, []string{"from", "max_depth", "to"}, sortedArgKeys(map[string]interface{}{
+		"to": "B", "from": "A", "max_depth": float64(3),
+	}))
+	assert.Empty(t, sortedArgKeys(nil))
+}
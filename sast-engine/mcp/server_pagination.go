@@ -0,0 +1,365 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shivasurya/code-pathfinder/sast-engine/graph/callgraph/core"
+)
+
+// defaultCallListPageSize bounds how many callees/callers toolGetCalleesPage
+// returns per page when the caller doesn't specify "limit".
+const defaultCallListPageSize = 50
+
+// callListFilter narrows a callee/caller list before pagination. The zero
+// value matches everything.
+type callListFilter struct {
+	stdlibOnly  *bool  // from filter.is_stdlib; nil means "don't care"
+	packageGlob string // from filter.package; matched against the FQN's package
+}
+
+// parseCallListFilter reads the optional "filter" argument — a map such as
+// {"is_stdlib": true} or {"package": "net/*"}. A missing or malformed filter
+// matches everything.
+func parseCallListFilter(args map[string]interface{}) callListFilter {
+	var f callListFilter
+	raw, ok := args["filter"].(map[string]interface{})
+	if !ok {
+		return f
+	}
+	if v, ok := raw["is_stdlib"].(bool); ok {
+		f.stdlibOnly = &v
+	}
+	if v, ok := raw["package"].(string); ok {
+		f.packageGlob = v
+	}
+	return f
+}
+
+// matches reports whether a call site targeting fqn (flagged isStdlib)
+// satisfies f.
+func (f callListFilter) matches(fqn string, isStdlib bool) bool {
+	if f.stdlibOnly != nil && *f.stdlibOnly != isStdlib {
+		return false
+	}
+	if f.packageGlob == "" {
+		return true
+	}
+	pkg := fqn
+	if idx := strings.LastIndex(fqn, "."); idx >= 0 {
+		pkg = fqn[:idx]
+	}
+	ok, err := filepath.Match(f.packageGlob, pkg)
+	return err == nil && ok
+}
+
+// encodeCallListCursor renders an offset as an opaque cursor string: callers are expected to treat it as a token, not an integer, so a
+// future revision can change what it encodes without breaking that contract.
+func encodeCallListCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeCallListCursor reverses encodeCallListCursor. An empty, missing, or
+// malformed cursor decodes to offset 0 — the start of the list — rather
+// than erroring, so a client that simply omits "cursor" on its first page
+// request gets that first page.
+func decodeCallListCursor(cursor string) int {
+	if cursor == "" {
+		return 0
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0
+	}
+	offset, err := strconv.Atoi(string(data))
+	if err != nil || offset < 0 {
+		return 0
+	}
+	return offset
+}
+
+// paginateCallSites filters sites with f, then returns up to limit of them
+// starting after cursor, plus the next_cursor to resume from ("" once the
+// page reaches the end of the filtered list). sites' own order is treated as
+// the stable ordering pagination walks — callers should pass
+// callGraph.Edges[fn]/ReverseEdges[fn]-derived CallSite slices as-is, never
+// re-sorted, or successive pages would double up or skip entries.
+func paginateCallSites(sites []core.CallSite, f callListFilter, limit int, cursor string) ([]core.CallSite, string) {
+	if limit <= 0 {
+		limit = defaultCallListPageSize
+	}
+
+	filtered := make([]core.CallSite, 0, len(sites))
+	for _, cs := range sites {
+		if f.matches(cs.TargetFQN, cs.IsStdlib) {
+			filtered = append(filtered, cs)
+		}
+	}
+
+	offset := decodeCallListCursor(cursor)
+	if offset >= len(filtered) {
+		return nil, ""
+	}
+	end := offset + limit
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+
+	nextCursor := ""
+	if end < len(filtered) {
+		nextCursor = encodeCallListCursor(end)
+	}
+	return filtered[offset:end], nextCursor
+}
+
+// callerCallSite finds callerFQN's call site targeting calleeFQN, the same
+// lookup toolGetCallers does for each entry in ReverseEdges[calleeFQN].
+func callerCallSite(callGraph *core.CallGraph, callerFQN, calleeFQN string) (core.CallSite, bool) {
+	for _, cs := range callGraph.CallSites[callerFQN] {
+		if cs.TargetFQN == calleeFQN {
+			return cs, true
+		}
+	}
+	return core.CallSite{}, false
+}
+
+// paginateCallers filters calleeFQN's callers (callGraph.ReverseEdges) with
+// f — is_stdlib matched against the caller's call site, package matched
+// against the caller's own FQN, since unlike a callee list every entry here
+// names a distinct function rather than a single call site's target — then
+// returns up to limit of them starting after cursor, plus the next_cursor.
+func paginateCallers(callGraph *core.CallGraph, calleeFQN string, f callListFilter, limit int, cursor string) ([]string, string) {
+	if limit <= 0 {
+		limit = defaultCallListPageSize
+	}
+
+	callers := callGraph.ReverseEdges[calleeFQN]
+	filtered := make([]string, 0, len(callers))
+	for _, callerFQN := range callers {
+		cs, _ := callerCallSite(callGraph, callerFQN, calleeFQN)
+		if f.matches(callerFQN, cs.IsStdlib) {
+			filtered = append(filtered, callerFQN)
+		}
+	}
+
+	offset := decodeCallListCursor(cursor)
+	if offset >= len(filtered) {
+		return nil, ""
+	}
+	end := offset + limit
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+
+	nextCursor := ""
+	if end < len(filtered) {
+		nextCursor = encodeCallListCursor(end)
+	}
+	return filtered[offset:end], nextCursor
+}
+
+// toolGetCallersPage is the paginated, filterable counterpart to
+// toolGetCallers: same arguments and enrichment as toolGetCalleesPage, over
+// callGraph.ReverseEdges instead of Edges. A paginated walk (follow
+// next_cursor until it's "") visits the same callers, in the same order, as
+// a single unpaginated call with a limit larger than the total count.
+func (s *Server) toolGetCallersPage(args map[string]interface{}) (string, bool) {
+	start := time.Now()
+	result, isError := s.getCallersPage(args)
+	s.observeToolCall("get_callers_page", args, start, len(result), strings.Contains(result, `"stdlib_info"`), errClassFor(isError))
+	return result, isError
+}
+
+func (s *Server) getCallersPage(args map[string]interface{}) (string, bool) {
+	fnArg, _ := args["function"].(string)
+	if strings.TrimSpace(fnArg) == "" {
+		return toolFindCallPathError("get_callers_page requires a 'function' argument")
+	}
+	fqn, ok := s.resolveFunctionArg(fnArg)
+	if !ok {
+		return toolFindCallPathError(fmt.Sprintf("function not found: %s", fnArg))
+	}
+	if s.callGraph == nil {
+		return toolFindCallPathError("no call graph loaded")
+	}
+
+	limit := defaultCallListPageSize
+	if v, ok := args["limit"].(float64); ok && v > 0 {
+		limit = int(v)
+	}
+	cursor, _ := args["cursor"].(string)
+	filter := parseCallListFilter(args)
+
+	page, nextCursor := paginateCallers(s.callGraph, fqn, filter, limit, cursor)
+
+	callers := make([]map[string]interface{}, 0, len(page))
+	for _, callerFQN := range page {
+		caller := map[string]interface{}{"function": callerFQN}
+		cs, found := callerCallSite(s.callGraph, callerFQN, fqn)
+		if found {
+			caller["file"] = cs.Location.File
+			caller["line"] = cs.Location.Line
+			if cs.IsStdlib {
+				caller["is_stdlib"] = true
+			}
+			if key, info := s.externalInfoForFQN(fqn, cs.IsStdlib); key != "" {
+				caller[key] = info
+			}
+		}
+		callers = append(callers, caller)
+	}
+
+	result := map[string]interface{}{
+		"function":    fqn,
+		"callers":     callers,
+		"next_cursor": nextCursor,
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return toolFindCallPathError(fmt.Sprintf("failed to marshal callers page: %v", err))
+	}
+	return string(data), false
+}
+
+// toolGetCalleesPage is the paginated, filterable counterpart to
+// toolGetCallees: "function" (required), plus optional "limit", "cursor",
+// and "filter" ({"is_stdlib": bool} and/or {"package": glob}). Each callee
+// carries the same is_stdlib/stdlib_info/module_info enrichment
+// describeCallPathHop attaches to a find_call_path hop. A paginated walk
+// (follow next_cursor until it's "") visits the same callees, in the same
+// order, as a single unpaginated call with a limit larger than the total
+// count.
+func (s *Server) toolGetCalleesPage(args map[string]interface{}) (string, bool) {
+	start := time.Now()
+	result, isError := s.getCalleesPage(args)
+	s.observeToolCall("get_callees_page", args, start, len(result), strings.Contains(result, `"stdlib_info"`), errClassFor(isError))
+	return result, isError
+}
+
+func (s *Server) getCalleesPage(args map[string]interface{}) (string, bool) {
+	fnArg, _ := args["function"].(string)
+	if strings.TrimSpace(fnArg) == "" {
+		return toolFindCallPathError("get_callees_page requires a 'function' argument")
+	}
+	fqn, ok := s.resolveFunctionArg(fnArg)
+	if !ok {
+		return toolFindCallPathError(fmt.Sprintf("function not found: %s", fnArg))
+	}
+	if s.callGraph == nil {
+		return toolFindCallPathError("no call graph loaded")
+	}
+
+	limit := defaultCallListPageSize
+	if v, ok := args["limit"].(float64); ok && v > 0 {
+		limit = int(v)
+	}
+	cursor, _ := args["cursor"].(string)
+	filter := parseCallListFilter(args)
+
+	page, nextCursor := paginateCallSites(s.callGraph.CallSites[fqn], filter, limit, cursor)
+
+	callees := make([]map[string]interface{}, 0, len(page))
+	for _, cs := range page {
+		callee := map[string]interface{}{
+			"target":    cs.Target,
+			"fqn":       cs.TargetFQN,
+			"file":      cs.Location.File,
+			"line":      cs.Location.Line,
+			"is_stdlib": cs.IsStdlib,
+		}
+		if key, info := s.externalInfoForFQN(cs.TargetFQN, cs.IsStdlib); key != "" {
+			callee[key] = info
+		}
+		callees = append(callees, callee)
+	}
+
+	result := map[string]interface{}{
+		"function":    fqn,
+		"callees":     callees,
+		"next_cursor": nextCursor,
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return toolFindCallPathError(fmt.Sprintf("failed to marshal callees page: %v", err))
+	}
+	return string(data), false
+}
+
+// ProgressNotifier receives partial-result batches during a streaming
+// callee walk. An MCP transport that supports it forwards each call as a
+// "notifications/progress" frame, letting an LLM client start reasoning
+// about the first callees while stdlib/module enrichment of the remaining
+// ones is still resolving, instead of waiting for the full list.
+type ProgressNotifier interface {
+	NotifyProgress(tool string, batch []map[string]interface{}, done bool)
+}
+
+// streamCalleesPage enriches fqn's call sites (filtered by f) in batches of
+// batchSize, calling notifier.NotifyProgress after each — the last batch is
+// sent with done=true; when no call site matches, a single empty batch is
+// sent with done=true. Enrichment (the stdlib/module lookup) happens per batch
+// rather than all upfront, so the first notification can reach the client
+// before the tail of a long callee list has been resolved.
+func (s *Server) streamCalleesPage(fqn string, f callListFilter, batchSize int, notifier ProgressNotifier) {
+	if batchSize <= 0 {
+		batchSize = defaultCallListPageSize
+	}
+	if notifier == nil {
+		return
+	}
+	if s.callGraph == nil {
+		notifier.NotifyProgress("get_callees_page", nil, true)
+		return
+	}
+
+	var filtered []core.CallSite
+	for _, cs := range s.callGraph.CallSites[fqn] {
+		if f.matches(cs.TargetFQN, cs.IsStdlib) {
+			filtered = append(filtered, cs)
+		}
+	}
+
+	for offset := 0; offset < len(filtered); offset += batchSize {
+		end := offset + batchSize
+		if end > len(filtered) {
+			end = len(filtered)
+		}
+
+		batch := make([]map[string]interface{}, 0, end-offset)
+		for _, cs := range filtered[offset:end] {
+			callee := map[string]interface{}{
+				"target":    cs.Target,
+				"fqn":       cs.TargetFQN,
+				"file":      cs.Location.File,
+				"line":      cs.Location.Line,
+				"is_stdlib": cs.IsStdlib,
+			}
+			if key, info := s.externalInfoForFQN(cs.TargetFQN, cs.IsStdlib); key != "" {
+				callee[key] = info
+			}
+			batch = append(batch, callee)
+		}
+
+		notifier.NotifyProgress("get_callees_page", batch, end >= len(filtered))
+	}
+
+	if len(filtered) == 0 {
+		notifier.NotifyProgress("get_callees_page", nil, true)
+	}
+}
+
+// errClassFor is the error-class label observeToolCall records for a
+// pagination tool's boolean isError result: every failure (missing or
+// unknown function, no call graph) is labelled "invalid_request", the same
+// label toolFindCallPath records for its failures.
+func errClassFor(isError bool) string {
+	if isError {
+		return "invalid_request"
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCallListFilter_MissingOrMalformed(t *testing.T) {
+	f := parseCallListFilter(nil)
+	assert.Nil(t, f.stdlibOnly)
+	assert.Empty(t, f.packageGlob)
+
+	f = parseCallListFilter(map[string]interface{}{"filter": "not-a-map"})
+	assert.Nil(t, f.stdlibOnly)
+}
+
+func TestParseCallListFilter_IsStdlibAndPackage(t *testing.T) {
+	f := parseCallListFilter(map[string]interface{}{
+		"filter": map[string]interface{}{"is_stdlib": true, "package": "net/*"},
+	})
+	require.NotNil(t, f.stdlibOnly)
+	assert.True(t, *f.stdlibOnly)
+	assert.Equal(t, "net/*", f.packageGlob)
+}
+
+func TestCallListFilter_Matches(t *testing.T) {
+	stdlibTrue := true
+	f := callListFilter{stdlibOnly: &stdlibTrue}
+	assert.True(t, f.matches("net/http.Get", true))
+	assert.False(t, f.matches("myapp.util.Helper", false))
+
+	f = callListFilter{packageGlob: "myapp/*"}
+	assert.True(t, f.matches("myapp/util.Helper", false))
+	assert.False(t, f.matches("net/http.Get", false))
+}
+
+func TestCallListCursor_RoundTrip(t *testing.T) {
+	cursor := encodeCallListCursor(42)
+	assert.Equal(t, 42, decodeCallListCursor(cursor))
+}
+
+func TestDecodeCallListCursor_InvalidDefaultsToZero(t *testing.T) {
+	assert.Equal(t, 0, decodeCallListCursor(""))
+	assert.Equal(t, 0, decodeCallListCursor("not-base64!!"))
+}
+
+func TestToolGetCalleesPage_PaginatedWalkMatchesUnpaginatedCall(t *testing.T) {
+	server := createGoTestServer()
+
+	var all []string
+	cursor := ""
+	for {
+		resultStr, isError := server.toolGetCalleesPage(map[string]interface{}{
+			"function": "Handle",
+			"limit":    float64(1),
+			"cursor":   cursor,
+		})
+		require.False(t, isError)
+
+		var page map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(resultStr), &page))
+		for _, c := range page["callees"].([]interface{}) {
+			all = append(all, c.(map[string]interface{})["fqn"].(string))
+		}
+
+		next, _ := page["next_cursor"].(string)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	assert.ElementsMatch(t, []string{"net/http.Get", "myapp.util.Helper"}, all)
+}
+
+func TestToolGetCalleesPage_FilterIsStdlibTrue(t *testing.T) {
+	server := createGoTestServer()
+
+	resultStr, isError := server.toolGetCalleesPage(map[string]interface{}{
+		"function": "Handle",
+		"filter":   map[string]interface{}{"is_stdlib": true},
+	})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+	callees := result["callees"].([]interface{})
+	require.Len(t, callees, 1)
+	assert.Equal(t, "net/http.Get", callees[0].(map[string]interface{})["fqn"])
+}
+
+func TestToolGetCalleesPage_MissingFunction(t *testing.T) {
+	server := createGoTestServer()
+	_, isError := server.toolGetCalleesPage(map[string]interface{}{})
+	assert.True(t, isError)
+}
+
+func TestToolGetCallersPage_ReturnsCallerOfHelper(t *testing.T) {
+	server := createGoTestServer()
+
+	resultStr, isError := server.toolGetCallersPage(map[string]interface{}{"function": "Helper"})
+	require.False(t, isError)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(resultStr), &result))
+	callers := result["callers"].([]interface{})
+	require.Len(t, callers, 1)
+	assert.Equal(t, "myapp.handler.Handle", callers[0].(map[string]interface{})["function"])
+}
+
+func TestToolGetCallersPage_UnknownFunction(t *testing.T) {
+	server := createGoTestServer()
+	_, isError := server.toolGetCallersPage(map[string]interface{}{"function": "does.not.Exist"})
+	assert.True(t, isError)
+}
+
+func TestStreamCalleesPage_DeliversBatchesThenDone(t *testing.T) {
+	server := createGoTestServer()
+
+	type notification struct {
+		batchLen int
+		done     bool
+	}
+	var received []notification
+	notifier := progressNotifierFunc(func(_ string, batch []map[string]interface{}, done bool) {
+		received = append(received, notification{batchLen: len(batch), done: done})
+	})
+
+	server.streamCalleesPage("myapp.handler.Handle", callListFilter{}, 1, notifier)
+
+	require.Len(t, received, 2)
+	assert.False(t, received[0].done)
+	assert.True(t, received[1].done)
+}
+
+func TestStreamCalleesPage_NilNotifierDoesNotPanic(t *testing.T) {
+	server := createGoTestServer()
+
+	assert.NotPanics(t, func() {
+		server.streamCalleesPage("myapp.handler.Handle", callListFilter{}, 1, nil)
+	})
+}
+
+func TestStreamCalleesPage_NilCallGraphStillNotifiesDone(t *testing.T) {
+	server := createGoTestServer()
+	server.callGraph = nil
+
+	var received []bool
+	notifier := progressNotifierFunc(func(_ string, batch []map[string]interface{}, done bool) {
+		received = append(received, done)
+	})
+
+	server.streamCalleesPage("myapp.handler.Handle", callListFilter{}, 1, notifier)
+
+	require.Len(t, received, 1)
+	assert.True(t, received[0])
+}
+
+// progressNotifierFunc adapts a plain function to ProgressNotifier, the way
+// http.HandlerFunc adapts a function to http.Handler.
+type progressNotifierFunc func(tool string, batch []map[string]interface{}, done bool)
+
+func (f progressNotifierFunc) NotifyProgress(tool string, batch []map[string]interface{}, done bool) {
+	f(tool, batch, done)
+}